@@ -28,6 +28,7 @@ import (
 	"golang.org/x/sync/errgroup"
 	"k8s.io/apimachinery/pkg/api/resource"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apiserver/pkg/server/healthz"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/leaderelection"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
@@ -95,6 +96,64 @@ func Run(opts *options.ControllerOptions, stopCh <-chan struct{}) error {
 		return nil
 	})
 
+	// Start the liveness and readiness probe server if it is enabled
+	leaderElectionHealthz := leaderelection.NewLeaderHealthzAdaptor(leaderElectionHealthzTimeout)
+	informersSynced := &informersSyncedCheck{}
+	readyz := []healthz.HealthChecker{healthz.PingHealthz, informersSynced}
+
+	// Check the controller's permissions on Secrets in the background, so that
+	// missing RBAC rules are reported at startup and fail readiness.
+	if opts.CheckSecretPermissions {
+		namespaces := []string{opts.Namespace}
+		if opts.Namespace == "" {
+			namespaces = append(namespaces, opts.ClusterResourceNamespace)
+		}
+		secretPermissions := &secretPermissionsCheck{}
+		readyz = append(readyz, secretPermissions)
+		g.Go(func() error {
+			secretPermissions.run(rootCtx, ctx.Client, namespaces)
+			return nil
+		})
+	}
+
+	if opts.HealthzListenAddress != "" {
+		healthzLn, err := net.Listen("tcp", opts.HealthzListenAddress)
+		if err != nil {
+			return fmt.Errorf("failed to listen on healthz address %s: %v", opts.HealthzListenAddress, err)
+		}
+		healthzServer := newHealthzServer(healthzLn,
+			[]healthz.HealthChecker{healthz.PingHealthz, leaderElectionHealthz},
+			readyz,
+		)
+
+		g.Go(func() error {
+			<-rootCtx.Done()
+			// allow a timeout for graceful shutdown
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			if err := healthzServer.Shutdown(ctx); err != nil {
+				return err
+			}
+			return nil
+		})
+		g.Go(func() error {
+			log.V(logf.InfoLevel).Info("starting healthz server", "address", healthzLn.Addr())
+			if err := healthzServer.Serve(healthzLn); err != http.ErrServerClosed {
+				return err
+			}
+			return nil
+		})
+	}
+
+	// Run the key generation benchmark in the background if it is enabled, so
+	// that it does not delay startup. It is not part of the errgroup, as
+	// generating a large RSA key cannot be interrupted and must not delay
+	// shutdown.
+	if opts.BenchmarkKeyGeneration {
+		go runKeyGenerationBenchmark(rootCtx, ctx.Metrics, keyGenerationBenchmarks)
+	}
+
 	// Start profiler if it is enabled
 	if opts.EnablePprof {
 		profilerLn, err := net.Listen("tcp", opts.PprofAddress)
@@ -138,7 +197,7 @@ func Run(opts *options.ControllerOptions, stopCh <-chan struct{}) error {
 			}
 
 			errorCh := make(chan error, 1)
-			if err := startLeaderElection(rootCtx, opts, ctx.Client, ctx.Recorder, leaderelection.LeaderCallbacks{
+			if err := startLeaderElection(rootCtx, opts, ctx.Client, ctx.Recorder, leaderElectionHealthz, leaderelection.LeaderCallbacks{
 				OnStartedLeading: func(_ context.Context) {
 					close(elected)
 				},
@@ -173,6 +232,7 @@ func Run(opts *options.ControllerOptions, stopCh <-chan struct{}) error {
 	case <-elected: // Don't launch the controllers unless we have been elected leader
 		// Continue with setting up controller
 	}
+	informersSynced.elected.Store(true)
 
 	for n, fn := range controller.Known() {
 		log := log.WithValues("controller", n)
@@ -216,6 +276,26 @@ func Run(opts *options.ControllerOptions, stopCh <-chan struct{}) error {
 		ctx.GWShared.Start(rootCtx.Done())
 	}
 
+	g.Go(func() error {
+		synced := true
+		for _, ok := range ctx.SharedInformerFactory.WaitForCacheSync(rootCtx.Done()) {
+			synced = synced && ok
+		}
+		for _, ok := range ctx.KubeSharedInformerFactory.WaitForCacheSync(rootCtx.Done()) {
+			synced = synced && ok
+		}
+		if utilfeature.DefaultFeatureGate.Enabled(feature.ExperimentalGatewayAPISupport) {
+			for _, ok := range ctx.GWShared.WaitForCacheSync(rootCtx.Done()) {
+				synced = synced && ok
+			}
+		}
+		if synced {
+			log.V(logf.DebugLevel).Info("informer caches have synced")
+			informersSynced.synced.Store(true)
+		}
+		return nil
+	})
+
 	err = g.Wait()
 	if err != nil {
 		return fmt.Errorf("error starting controller: %v", err)
@@ -260,6 +340,19 @@ func buildControllerContextFactory(ctx context.Context, opts *options.Controller
 	}
 
 	ACMEHTTP01SolverRunAsNonRoot := opts.ACMEHTTP01SolverRunAsNonRoot
+
+	// An empty header name disables sending a correlation ID.
+	var correlationIDHeader string
+	if opts.ACMEIssuerCorrelationID {
+		correlationIDHeader = opts.ACMEIssuerCorrelationIDHeader
+	}
+
+	// The zero value disables the bootstrap grace period.
+	var bootstrapDeadline time.Time
+	if opts.ACMEIssuerBootstrapGracePeriod > 0 {
+		bootstrapDeadline = time.Now().Add(opts.ACMEIssuerBootstrapGracePeriod)
+	}
+
 	acmeAccountRegistry := accounts.NewDefaultRegistry()
 
 	ctxFactory, err := controller.NewContextFactory(ctx, controller.ContextOptions{
@@ -281,7 +374,19 @@ func buildControllerContextFactory(ctx context.Context, opts *options.Controller
 			ACMEHTTP01SolverRunAsNonRoot:      ACMEHTTP01SolverRunAsNonRoot,
 			HTTP01SolverImage:                 opts.ACMEHTTP01SolverImage,
 			// Allows specifying a list of custom nameservers to perform HTTP01 checks on.
-			HTTP01SolverNameservers: opts.ACMEHTTP01SolverNameservers,
+			HTTP01SolverNameservers:        opts.ACMEHTTP01SolverNameservers,
+			HTTP01SelfCheckAddress:         opts.ACMEHTTP01SelfCheckAddress,
+			HTTP01SelfCheckHost:            opts.ACMEHTTP01SelfCheckHost,
+			HTTP01SelfCheckFollowRedirects: opts.ACMEHTTP01SelfCheckFollowRedirects,
+
+			OrderTimeout: opts.ACMEOrderTimeout,
+
+			IssuerConditionReasonPrefix: opts.ACMEIssuerConditionReasonPrefix,
+			IssuerCorrelationIDHeader:   correlationIDHeader,
+			IssuerEventAnnotationKeys:   opts.ACMEIssuerEventAnnotationKeys,
+			IssuerClockSkewThreshold:    opts.ACMEIssuerClockSkewThreshold,
+			IssuerBootstrapDeadline:     bootstrapDeadline,
+			IssuerHTTPTimeout:           opts.ACMEIssuerHTTPTimeout,
 
 			DNS01Nameservers:        nameservers,
 			DNS01CheckRetryPeriod:   opts.DNS01CheckRetryPeriod,
@@ -291,7 +396,8 @@ func buildControllerContextFactory(ctx context.Context, opts *options.Controller
 		},
 
 		SchedulerOptions: controller.SchedulerOptions{
-			MaxConcurrentChallenges: opts.MaxConcurrentChallenges,
+			MaxConcurrentChallenges:               opts.MaxConcurrentChallenges,
+			MaxConcurrentChallengesPerDNSProvider: opts.MaxConcurrentChallengesPerDNSProvider,
 		},
 
 		IssuerOptions: controller.IssuerOptions{
@@ -308,8 +414,9 @@ func buildControllerContextFactory(ctx context.Context, opts *options.Controller
 		},
 
 		CertificateOptions: controller.CertificateOptions{
-			EnableOwnerRef:           opts.EnableCertificateOwnerRef,
-			CopiedAnnotationPrefixes: opts.CopiedAnnotationPrefixes,
+			EnableOwnerRef:              opts.EnableCertificateOwnerRef,
+			CopiedAnnotationPrefixes:    opts.CopiedAnnotationPrefixes,
+			DefaultRevisionHistoryLimit: opts.DefaultRevisionHistoryLimit,
 		},
 	})
 	if err != nil {
@@ -319,7 +426,7 @@ func buildControllerContextFactory(ctx context.Context, opts *options.Controller
 	return ctxFactory, nil
 }
 
-func startLeaderElection(ctx context.Context, opts *options.ControllerOptions, leaderElectionClient kubernetes.Interface, recorder record.EventRecorder, callbacks leaderelection.LeaderCallbacks) error {
+func startLeaderElection(ctx context.Context, opts *options.ControllerOptions, leaderElectionClient kubernetes.Interface, recorder record.EventRecorder, healthzAdaptor *leaderelection.HealthzAdaptor, callbacks leaderelection.LeaderCallbacks) error {
 	// Identity used to distinguish between multiple controller manager instances
 	id, err := os.Hostname()
 	if err != nil {
@@ -353,6 +460,7 @@ func startLeaderElection(ctx context.Context, opts *options.ControllerOptions, l
 		RetryPeriod:     opts.LeaderElectionRetryPeriod,
 		ReleaseOnCancel: true,
 		Callbacks:       callbacks,
+		WatchDog:        healthzAdaptor,
 	})
 	if err != nil {
 		return err
@@ -0,0 +1,74 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/apiserver/pkg/server/healthz"
+)
+
+const (
+	healthzServerReadTimeout  = 8 * time.Second
+	healthzServerWriteTimeout = 8 * time.Second
+
+	// leaderElectionHealthzTimeout is how long the controller may fail to
+	// renew its leader election lease beyond the lease deadline before the
+	// liveness probe fails.
+	leaderElectionHealthzTimeout = 20 * time.Second
+)
+
+// informersSyncedCheck is a readiness check that fails once the controller
+// has been elected leader and until its informer caches have synced. Replicas
+// that are waiting to be elected run no controllers, so they are ready.
+type informersSyncedCheck struct {
+	elected atomic.Bool
+	synced  atomic.Bool
+}
+
+var _ healthz.HealthChecker = &informersSyncedCheck{}
+
+func (c *informersSyncedCheck) Name() string {
+	return "informer-sync"
+}
+
+func (c *informersSyncedCheck) Check(_ *http.Request) error {
+	if c.elected.Load() && !c.synced.Load() {
+		return errors.New("the informer caches have not synced yet")
+	}
+	return nil
+}
+
+// newHealthzServer returns a HTTP server serving the /livez and /healthz
+// liveness endpoints, and the /readyz readiness endpoint.
+func newHealthzServer(ln net.Listener, livez []healthz.HealthChecker, readyz []healthz.HealthChecker) *http.Server {
+	mux := http.NewServeMux()
+	healthz.InstallLivezHandler(mux, livez...)
+	healthz.InstallHandler(mux, livez...)
+	healthz.InstallReadyzHandler(mux, readyz...)
+
+	return &http.Server{
+		Addr:         ln.Addr().String(),
+		ReadTimeout:  healthzServerReadTimeout,
+		WriteTimeout: healthzServerWriteTimeout,
+		Handler:      mux,
+	}
+}
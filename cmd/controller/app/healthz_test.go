@@ -0,0 +1,117 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+
+	"k8s.io/apiserver/pkg/server/healthz"
+)
+
+func TestInformersSyncedCheck(t *testing.T) {
+	tests := map[string]struct {
+		elected bool
+		synced  bool
+		expErr  bool
+	}{
+		"a replica that has not been elected leader is ready": {
+			elected: false,
+			synced:  false,
+			expErr:  false,
+		},
+		"the leader is not ready until its informer caches have synced": {
+			elected: true,
+			synced:  false,
+			expErr:  true,
+		},
+		"the leader is ready once its informer caches have synced": {
+			elected: true,
+			synced:  true,
+			expErr:  false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			check := &informersSyncedCheck{}
+			check.elected.Store(test.elected)
+			check.synced.Store(test.synced)
+
+			err := check.Check(nil)
+			if test.expErr != (err != nil) {
+				t.Errorf("unexpected error, exp=%t got=%v", test.expErr, err)
+			}
+		})
+	}
+}
+
+func TestNewHealthzServer(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	failingCheck := healthz.NamedCheck("failing", func(_ *http.Request) error {
+		return errors.New("not ready")
+	})
+	server := newHealthzServer(ln,
+		[]healthz.HealthChecker{healthz.PingHealthz},
+		[]healthz.HealthChecker{healthz.PingHealthz, failingCheck},
+	)
+	go server.Serve(ln)
+	defer server.Close()
+
+	tests := map[string]struct {
+		path          string
+		expStatusCode int
+	}{
+		"the liveness endpoint succeeds if all liveness checks succeed": {
+			path:          "/livez",
+			expStatusCode: http.StatusOK,
+		},
+		"the legacy healthz endpoint serves the liveness checks": {
+			path:          "/healthz",
+			expStatusCode: http.StatusOK,
+		},
+		"the readiness endpoint fails if any readiness check fails": {
+			path:          "/readyz",
+			expStatusCode: http.StatusInternalServerError,
+		},
+		"individual readiness checks are served": {
+			path:          "/readyz/ping",
+			expStatusCode: http.StatusOK,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp, err := http.Get(fmt.Sprintf("http://%s%s", ln.Addr(), test.path))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != test.expStatusCode {
+				t.Errorf("unexpected status code, exp=%d got=%d", test.expStatusCode, resp.StatusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"crypto"
+	"strconv"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// keyGenerationBenchmark is a single key algorithm and size that is timed by
+// the key generation benchmark.
+type keyGenerationBenchmark struct {
+	algorithm cmapi.PrivateKeyAlgorithm
+	size      int
+	generate  func() (crypto.Signer, error)
+}
+
+// keyGenerationBenchmarks contains every key algorithm and size that can be
+// configured on a Certificate or generated for an ACME account, ordered from
+// the quickest to the slowest to generate. The size of Ed25519 keys cannot be
+// configured, so it is 0.
+var keyGenerationBenchmarks = []keyGenerationBenchmark{
+	{cmapi.Ed25519KeyAlgorithm, 0, func() (crypto.Signer, error) { return pki.GenerateEd25519PrivateKey() }},
+	{cmapi.ECDSAKeyAlgorithm, pki.ECCurve256, func() (crypto.Signer, error) { return pki.GenerateECPrivateKey(pki.ECCurve256) }},
+	{cmapi.ECDSAKeyAlgorithm, pki.ECCurve384, func() (crypto.Signer, error) { return pki.GenerateECPrivateKey(pki.ECCurve384) }},
+	{cmapi.ECDSAKeyAlgorithm, pki.ECCurve521, func() (crypto.Signer, error) { return pki.GenerateECPrivateKey(pki.ECCurve521) }},
+	{cmapi.RSAKeyAlgorithm, 2048, func() (crypto.Signer, error) { return pki.GenerateRSAPrivateKey(2048) }},
+	{cmapi.RSAKeyAlgorithm, 3072, func() (crypto.Signer, error) { return pki.GenerateRSAPrivateKey(3072) }},
+	{cmapi.RSAKeyAlgorithm, 4096, func() (crypto.Signer, error) { return pki.GenerateRSAPrivateKey(4096) }},
+	{cmapi.RSAKeyAlgorithm, pki.MaxRSAKeySize, func() (crypto.Signer, error) { return pki.GenerateRSAPrivateKey(pki.MaxRSAKeySize) }},
+}
+
+// runKeyGenerationBenchmark generates one key for each of the given
+// benchmarks, usually keyGenerationBenchmarks, and records how long each took
+// as a log line and as a metric. It is intended to be run in the background at
+// startup, so that operators can judge how expensive key generation is on the
+// hardware the controller runs on. It stops early if the context is cancelled,
+// dropping the result of the key that was being generated at the time.
+func runKeyGenerationBenchmark(ctx context.Context, m *metrics.Metrics, benchmarks []keyGenerationBenchmark) {
+	log := logf.FromContext(ctx, "key-generation-benchmark")
+	log.V(logf.InfoLevel).Info("starting key generation benchmark")
+
+	for _, b := range benchmarks {
+		if ctx.Err() != nil {
+			log.V(logf.InfoLevel).Info("stopping key generation benchmark as the controller is shutting down")
+			return
+		}
+
+		start := time.Now()
+		if _, err := b.generate(); err != nil {
+			log.Error(err, "failed to generate private key", "algorithm", b.algorithm, "size", b.size)
+			continue
+		}
+		duration := time.Since(start)
+		if ctx.Err() != nil {
+			log.V(logf.InfoLevel).Info("stopping key generation benchmark as the controller is shutting down")
+			return
+		}
+
+		log.V(logf.InfoLevel).Info("generated private key", "algorithm", b.algorithm, "size", b.size, "duration", duration)
+		m.SetKeyGenerationBenchmarkDuration(duration, string(b.algorithm), strconv.Itoa(b.size))
+	}
+
+	log.V(logf.InfoLevel).Info("finished key generation benchmark")
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+func TestRunKeyGenerationBenchmark(t *testing.T) {
+	ed25519Metric := `certmanager_key_generation_benchmark_duration_seconds{algorithm="Ed25519",size="0"}`
+	ecdsaMetric := `certmanager_key_generation_benchmark_duration_seconds{algorithm="ECDSA",size="256"}`
+	failedMetric := `certmanager_key_generation_benchmark_duration_seconds{algorithm="RSA",size="2048"}`
+
+	tests := map[string]struct {
+		// cancelAfter is the number of keys generated before the context
+		// is cancelled. Zero means the context is never cancelled.
+		cancelAfter  int
+		expGenerated int
+		expMetrics   []string
+		expNoMetrics []string
+	}{
+		"a metric is set for every key that was generated": {
+			expGenerated: 3,
+			expMetrics:   []string{ed25519Metric, ecdsaMetric},
+			expNoMetrics: []string{failedMetric},
+		},
+		"the benchmark stops once the context is cancelled, without recording the key being generated": {
+			cancelAfter:  2,
+			expGenerated: 2,
+			expMetrics:   []string{ed25519Metric},
+			expNoMetrics: []string{ecdsaMetric, failedMetric},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			generated := 0
+			generate := func(fn func() (crypto.Signer, error)) func() (crypto.Signer, error) {
+				return func() (crypto.Signer, error) {
+					generated++
+					if generated == test.cancelAfter {
+						cancel()
+					}
+					return fn()
+				}
+			}
+			benchmarks := []keyGenerationBenchmark{
+				{cmapi.Ed25519KeyAlgorithm, 0, generate(func() (crypto.Signer, error) { return pki.GenerateEd25519PrivateKey() })},
+				{cmapi.ECDSAKeyAlgorithm, pki.ECCurve256, generate(func() (crypto.Signer, error) { return pki.GenerateECPrivateKey(pki.ECCurve256) })},
+				{cmapi.RSAKeyAlgorithm, 2048, generate(func() (crypto.Signer, error) { return nil, errors.New("failed") })},
+			}
+
+			m := metrics.New(logf.Log, fakeclock.NewFakeClock(time.Now()))
+			runKeyGenerationBenchmark(ctx, m, benchmarks)
+
+			if generated != test.expGenerated {
+				t.Errorf("unexpected number of keys generated, exp=%d got=%d", test.expGenerated, generated)
+			}
+
+			got := scrapeMetrics(t, m)
+			for _, metric := range test.expMetrics {
+				if !strings.Contains(got, metric) {
+					t.Errorf("expected metric %s to be set, got:\n%s", metric, got)
+				}
+			}
+			for _, metric := range test.expNoMetrics {
+				if strings.Contains(got, metric) {
+					t.Errorf("expected metric %s not to be set, got:\n%s", metric, got)
+				}
+			}
+		})
+	}
+}
+
+// scrapeMetrics returns the metrics served by the metrics server of m.
+func scrapeMetrics(t *testing.T, m *metrics.Metrics) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := m.NewServer(ln)
+	go server.Serve(ln)
+	defer server.Close()
+
+	resp, err := http.Get(fmt.Sprintf("http://%s/metrics", ln.Addr()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(body)
+}
@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"regexp"
 	"strings"
 	"time"
 
@@ -83,6 +84,45 @@ type ControllerOptions struct {
 	ACMEHTTP01SolverRunAsNonRoot          bool
 	// Allows specifying a list of custom nameservers to perform HTTP01 checks on.
 	ACMEHTTP01SolverNameservers []string
+	// ACMEHTTP01SelfCheckAddress is the host and port that HTTP01 self
+	// checks connect to instead of the challenge domain.
+	ACMEHTTP01SelfCheckAddress string
+	// ACMEHTTP01SelfCheckHost overrides the Host header of HTTP01 self
+	// check requests.
+	ACMEHTTP01SelfCheckHost string
+	// ACMEHTTP01SelfCheckFollowRedirects controls whether HTTP01 self
+	// checks follow redirects returned by the solver.
+	ACMEHTTP01SelfCheckFollowRedirects bool
+	// ACMEOrderTimeout is the time after which an Order that is still
+	// waiting for its Challenges to complete is marked as failed. Zero
+	// disables the timeout.
+	ACMEOrderTimeout time.Duration
+	// ACMEIssuerConditionReasonPrefix is prepended to the reason of every
+	// condition set on ACME Issuers and ClusterIssuers.
+	ACMEIssuerConditionReasonPrefix string
+	// ACMEIssuerCorrelationID determines whether a unique correlation ID is
+	// sent in a header on every request made to the ACME server while
+	// setting up an ACME Issuer or ClusterIssuer.
+	ACMEIssuerCorrelationID bool
+	// ACMEIssuerCorrelationIDHeader is the name of the header in which the
+	// correlation ID is sent.
+	ACMEIssuerCorrelationIDHeader string
+	// ACMEIssuerEventAnnotationKeys is a list of annotation keys whose values
+	// on ACME Issuers and ClusterIssuers are attached to the events emitted
+	// while setting them up.
+	ACMEIssuerEventAnnotationKeys []string
+	// ACMEIssuerClockSkewThreshold is the difference between the local clock
+	// and the clock of the ACME server above which a warning event is emitted
+	// while setting up an ACME Issuer or ClusterIssuer. Zero disables the check.
+	ACMEIssuerClockSkewThreshold time.Duration
+	// ACMEIssuerBootstrapGracePeriod is the period after the controller has
+	// started during which transient errors while setting up ACME Issuers
+	// and ClusterIssuers set their Ready condition to Unknown rather than
+	// False. Zero disables the grace period.
+	ACMEIssuerBootstrapGracePeriod time.Duration
+	// ACMEIssuerHTTPTimeout is the maximum time that an individual HTTP
+	// request to an ACME server may take.
+	ACMEIssuerHTTPTimeout time.Duration
 
 	ClusterIssuerAmbientCredentials bool
 	IssuerAmbientCredentials        bool
@@ -101,21 +141,45 @@ type ControllerOptions struct {
 
 	EnableCertificateOwnerRef bool
 
+	// DefaultRevisionHistoryLimit is the maximum number of CertificateRequest
+	// revisions to keep for Certificates that do not set
+	// spec.revisionHistoryLimit. If zero, no default limit is applied.
+	DefaultRevisionHistoryLimit int
+
 	// The number of concurrent workers for each controller.
 	NumberOfConcurrentWorkers int
 	// MaxConcurrentChallenges determines the maximum number of challenges that can be
 	// scheduled as 'processing' at once.
 	MaxConcurrentChallenges int
+	// MaxConcurrentChallengesPerDNSProvider determines the maximum number of
+	// DNS01 challenges for a single DNS provider that can be scheduled as
+	// 'processing' at once.
+	MaxConcurrentChallengesPerDNSProvider int
 
 	// The host and port address, separated by a ':', that the Prometheus server
 	// should expose metrics on.
 	MetricsListenAddress string
+	// The host and port address, separated by a ':', that the liveness and
+	// readiness probe endpoints should be served on. Probes are disabled if
+	// this is empty.
+	HealthzListenAddress string
 	// PprofAddress is the address on which Go profiler will run. Should be
 	// in form <host>:<port>.
 	PprofAddress string
 	// EnablePprof determines whether pprof should be enabled.
 	EnablePprof bool
 
+	// BenchmarkKeyGeneration determines whether the time it takes to
+	// generate each supported private key algorithm and size should be
+	// measured once at startup, and recorded as log lines and metrics.
+	BenchmarkKeyGeneration bool
+
+	// CheckSecretPermissions determines whether the controller should check
+	// at startup that its RBAC permissions allow it to manage Secrets, log an
+	// error for each missing permission and fail its readiness check if any
+	// permission is missing.
+	CheckSecretPermissions bool
+
 	// DNSO1CheckRetryPeriod is the period of time after which to check if
 	// challenge URL can be reached by cert-manager controller. This is used
 	// for both DNS-01 and HTTP-01 challenges.
@@ -144,12 +208,27 @@ const (
 	defaultTLSACMEIssuerGroup        = cm.GroupName
 	defaultEnableCertificateOwnerRef = false
 
+	defaultDefaultRevisionHistoryLimit = 0
+
 	defaultDNS01RecursiveNameserversOnly = false
 
-	defaultNumberOfConcurrentWorkers = 5
-	defaultMaxConcurrentChallenges   = 60
+	defaultACMEIssuerConditionReasonPrefix = ""
+	defaultACMEIssuerCorrelationID         = false
+	defaultACMEIssuerCorrelationIDHeader   = "X-Request-ID"
+	defaultACMEIssuerClockSkewThreshold    = time.Minute
+	defaultACMEIssuerBootstrapGracePeriod  = time.Duration(0)
+	defaultACMEIssuerHTTPTimeout           = 90 * time.Second
+	defaultACMEOrderTimeout                = time.Duration(0)
+
+	defaultNumberOfConcurrentWorkers             = 5
+	defaultMaxConcurrentChallenges               = 60
+	defaultMaxConcurrentChallengesPerDNSProvider = 0
 
 	defaultPrometheusMetricsServerAddress = "0.0.0.0:9402"
+	defaultHealthzServerAddress           = "0.0.0.0:9403"
+
+	defaultBenchmarkKeyGeneration = false
+	defaultCheckSecretPermissions = true
 
 	// default time period to wait between checking DNS01 and HTTP01 challenge propagation
 	defaultDNS01CheckRetryPeriod = 10 * time.Second
@@ -162,6 +241,7 @@ var (
 	defaultACMEHTTP01SolverResourceLimitsCPU     = "100m"
 	defaultACMEHTTP01SolverResourceLimitsMemory  = "64Mi"
 	defaultACMEHTTP01SolverRunAsNonRoot          = true
+	defaultACMEHTTP01SelfCheckFollowRedirects    = true
 
 	defaultAutoCertificateAnnotations = []string{"kubernetes.io/tls-acme"}
 
@@ -227,6 +307,14 @@ var (
 	}
 )
 
+// conditionReasonPrefixRegexp matches prefixes which, when prepended to a
+// valid condition reason, still yield a valid condition reason.
+var conditionReasonPrefixRegexp = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_,:]*$`)
+
+// headerNameRegexp matches HTTP header names made up of letters, digits and
+// hyphens, which is the form that custom headers are expected to take.
+var headerNameRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]*$`)
+
 func NewControllerOptions() *ControllerOptions {
 	return &ControllerOptions{
 		APIServerHost:                     defaultAPIServerHost,
@@ -247,15 +335,25 @@ func NewControllerOptions() *ControllerOptions {
 		DefaultIssuerGroup:                defaultTLSACMEIssuerGroup,
 		DefaultAutoCertificateAnnotations: defaultAutoCertificateAnnotations,
 		ACMEHTTP01SolverNameservers:       []string{},
+		ACMEIssuerConditionReasonPrefix:   defaultACMEIssuerConditionReasonPrefix,
+		ACMEIssuerCorrelationID:           defaultACMEIssuerCorrelationID,
+		ACMEIssuerCorrelationIDHeader:     defaultACMEIssuerCorrelationIDHeader,
+		ACMEIssuerEventAnnotationKeys:     []string{},
+		ACMEIssuerClockSkewThreshold:      defaultACMEIssuerClockSkewThreshold,
+		ACMEIssuerBootstrapGracePeriod:    defaultACMEIssuerBootstrapGracePeriod,
+		ACMEIssuerHTTPTimeout:             defaultACMEIssuerHTTPTimeout,
 		DNS01RecursiveNameservers:         []string{},
 		DNS01RecursiveNameserversOnly:     defaultDNS01RecursiveNameserversOnly,
 		EnableCertificateOwnerRef:         defaultEnableCertificateOwnerRef,
 		MetricsListenAddress:              defaultPrometheusMetricsServerAddress,
+		HealthzListenAddress:              defaultHealthzServerAddress,
 		NumberOfConcurrentWorkers:         defaultNumberOfConcurrentWorkers,
 		MaxConcurrentChallenges:           defaultMaxConcurrentChallenges,
 		DNS01CheckRetryPeriod:             defaultDNS01CheckRetryPeriod,
 		EnablePprof:                       cmdutil.DefaultEnableProfiling,
 		PprofAddress:                      cmdutil.DefaultProfilerAddr,
+		BenchmarkKeyGeneration:            defaultBenchmarkKeyGeneration,
+		CheckSecretPermissions:            defaultCheckSecretPermissions,
 	}
 }
 
@@ -327,6 +425,48 @@ func (s *ControllerOptions) AddFlags(fs *pflag.FlagSet) {
 		[]string{}, "A list of comma separated dns server endpoints used for "+
 			"ACME HTTP01 check requests. This should be a list containing host and "+
 			"port, for example 8.8.8.8:53,8.8.4.4:53")
+	fs.StringVar(&s.ACMEHTTP01SelfCheckAddress, "acme-http01-self-check-address", "", ""+
+		"The host and port that ACME HTTP01 self checks connect to instead of the challenge domain on port 80, "+
+		"for example ingress-nginx-controller.ingress-nginx.svc:80. The request is still sent with the challenge "+
+		"domain as its Host header. This is useful when the controller cannot reach the public address of the "+
+		"challenge domain, such as behind load balancers that require the PROXY protocol.")
+	fs.StringVar(&s.ACMEHTTP01SelfCheckHost, "acme-http01-self-check-host", "", ""+
+		"The Host header sent with ACME HTTP01 self check requests instead of the challenge domain. "+
+		"This is useful when the self check address routes requests by a different host name. "+
+		"Redirects returned to a different host are sent with the host of the redirect.")
+	fs.BoolVar(&s.ACMEHTTP01SelfCheckFollowRedirects, "acme-http01-self-check-follow-redirects", defaultACMEHTTP01SelfCheckFollowRedirects, ""+
+		"Whether ACME HTTP01 self checks follow redirects returned by the solver. If false, a redirect "+
+		"fails the self check.")
+	fs.DurationVar(&s.ACMEOrderTimeout, "acme-order-timeout", defaultACMEOrderTimeout, ""+
+		"The time after which an ACME Order that is still waiting for its challenges to complete is marked as failed, "+
+		"and its challenges and solver resources are cleaned up. The failure reason includes the reason each challenge "+
+		"has not completed, and is surfaced on the CertificateRequest and Certificate. Set to 0 to disable the timeout.")
+
+	fs.StringVar(&s.ACMEIssuerConditionReasonPrefix, "acme-issuer-condition-reason-prefix", defaultACMEIssuerConditionReasonPrefix, ""+
+		"A prefix that is prepended to the reason of every condition set on ACME Issuers and ClusterIssuers, "+
+		"for example 'TeamA'. This is useful to avoid collisions between tenants in multi-tenant monitoring setups. "+
+		"The prefix must start with a letter and may only contain letters, digits, '_', ',' and ':'.")
+	fs.BoolVar(&s.ACMEIssuerCorrelationID, "acme-issuer-correlation-id", defaultACMEIssuerCorrelationID, ""+
+		"If true, a unique correlation ID is generated each time an ACME Issuer or ClusterIssuer is set up, "+
+		"sent in a header on every request made to the ACME server during setup, and logged. "+
+		"This can be used to correlate reconciles with the logs of the ACME server.")
+	fs.StringVar(&s.ACMEIssuerCorrelationIDHeader, "acme-issuer-correlation-id-header", defaultACMEIssuerCorrelationIDHeader, ""+
+		"The name of the header in which the correlation ID is sent when acme-issuer-correlation-id is enabled.")
+	fs.StringSliceVar(&s.ACMEIssuerEventAnnotationKeys, "acme-issuer-event-annotation-keys", []string{}, ""+
+		"A list of comma separated annotation keys, for example 'example.com/team,example.com/environment'. "+
+		"The values of these annotations on an ACME Issuer or ClusterIssuer are attached as annotations to the events "+
+		"emitted while registering and verifying its ACME account, so that events can be filtered by them.")
+	fs.DurationVar(&s.ACMEIssuerClockSkewThreshold, "acme-issuer-clock-skew-threshold", defaultACMEIssuerClockSkewThreshold, ""+
+		"The difference between the local clock and the clock of the ACME server, as reported in the Date header of its responses, "+
+		"above which an ACMEClockSkew warning event is emitted while setting up an ACME Issuer or ClusterIssuer. "+
+		"Set to 0 to disable the check.")
+	fs.DurationVar(&s.ACMEIssuerHTTPTimeout, "acme-issuer-http-timeout", defaultACMEIssuerHTTPTimeout, ""+
+		"The maximum time that an individual HTTP request to an ACME server may take, including reading the response. "+
+		"Increase this when ACME servers are reached through slow proxies.")
+	fs.DurationVar(&s.ACMEIssuerBootstrapGracePeriod, "acme-issuer-bootstrap-grace-period", defaultACMEIssuerBootstrapGracePeriod, ""+
+		"The period after the controller has started during which transient errors while setting up an ACME Issuer or ClusterIssuer "+
+		"set its Ready condition to Unknown with reason ACMEBootstrapping, rather than to False. "+
+		"This avoids alerts caused by errors that resolve themselves shortly after a restart. Set to 0 to disable the grace period.")
 
 	fs.BoolVar(&s.ClusterIssuerAmbientCredentials, "cluster-issuer-ambient-credentials", defaultClusterIssuerAmbientCredentials, ""+
 		"Whether a cluster-issuer may make use of ambient credentials for issuers. 'Ambient Credentials' are credentials drawn from the environment, metadata services, or local files which are not explicitly configured in the ClusterIssuer API object. "+
@@ -364,21 +504,39 @@ func (s *ControllerOptions) AddFlags(fs *pflag.FlagSet) {
 		"from Certificate to CertificateRequest and Order, as well as from CertificateSigningRequest to Order, by passing a list of annotation key prefixes."+
 		"A prefix starting with a dash(-) specifies an annotation that shouldn't be copied. Example: '*,-kubectl.kuberenetes.io/'- all annotations"+
 		"will be copied apart from the ones where the key is prefixed with 'kubectl.kubernetes.io/'.")
+	fs.IntVar(&s.DefaultRevisionHistoryLimit, "default-revision-history-limit", defaultDefaultRevisionHistoryLimit, ""+
+		"The maximum number of CertificateRequest revisions to keep for Certificates that do not set spec.revisionHistoryLimit. "+
+		"Older CertificateRequests, and the ACME Orders and Challenges they own, are garbage collected. Set to 0 to keep all revisions.")
 
 	fs.IntVar(&s.NumberOfConcurrentWorkers, "concurrent-workers", defaultNumberOfConcurrentWorkers, ""+
 		"The number of concurrent workers for each controller.")
 	fs.IntVar(&s.MaxConcurrentChallenges, "max-concurrent-challenges", defaultMaxConcurrentChallenges, ""+
 		"The maximum number of challenges that can be scheduled as 'processing' at once.")
+	fs.IntVar(&s.MaxConcurrentChallengesPerDNSProvider, "max-concurrent-challenges-per-dns-provider", defaultMaxConcurrentChallengesPerDNSProvider, ""+
+		"The maximum number of DNS01 challenges for a single DNS provider that can be scheduled as 'processing' at once, "+
+		"to avoid hitting the rate limits of the DNS provider's API. Set to 0 to disable the per-provider limit.")
 	fs.DurationVar(&s.DNS01CheckRetryPeriod, "dns01-check-retry-period", defaultDNS01CheckRetryPeriod, ""+
 		"The duration the controller should wait between a propagation check. Despite the name, this flag is used to configure the wait period for both DNS01 and HTTP01 challenge propagation checks. For DNS01 challenges the propagation check verifies that a TXT record with the challenge token has been created. For HTTP01 challenges the propagation check verifies that the challenge token is served at the challenge URL."+
 		"This should be a valid duration string, for example 180s or 1h")
 
 	fs.StringVar(&s.MetricsListenAddress, "metrics-listen-address", defaultPrometheusMetricsServerAddress, ""+
 		"The host and port that the metrics endpoint should listen on.")
+	fs.StringVar(&s.HealthzListenAddress, "healthz-listen-address", defaultHealthzServerAddress, ""+
+		"The host and port that the /livez, /readyz and /healthz probe endpoints should listen on. "+
+		"Set to an empty string to disable the probe endpoints.")
 	fs.BoolVar(&s.EnablePprof, "enable-profiling", cmdutil.DefaultEnableProfiling, ""+
 		"Enable profiling for controller.")
 	fs.StringVar(&s.PprofAddress, "profiler-address", cmdutil.DefaultProfilerAddr,
 		"The host and port that Go profiler should listen on, i.e localhost:6060. Ensure that profiler is not exposed on a public address. Profiler will be served at /debug/pprof.")
+	fs.BoolVar(&s.BenchmarkKeyGeneration, "benchmark-key-generation", defaultBenchmarkKeyGeneration, ""+
+		"If true, the time it takes to generate a private key of each supported algorithm and size "+
+		"is measured once in the background at startup, and reported in the logs and in the "+
+		"certmanager_key_generation_benchmark_duration_seconds metric. This can be used to decide "+
+		"whether the hardware the controller runs on is suitable for generating large RSA keys.")
+	fs.BoolVar(&s.CheckSecretPermissions, "check-secret-permissions", defaultCheckSecretPermissions, ""+
+		"If true, the controller checks at startup, using SelfSubjectAccessReviews, that its RBAC "+
+		"permissions allow it to get, list, watch, create, update and delete Secrets in the namespaces "+
+		"it manages. Each missing permission is logged as an error, and fails the /readyz readiness check.")
 }
 
 func (o *ControllerOptions) Validate() error {
@@ -406,6 +564,42 @@ func (o *ControllerOptions) Validate() error {
 		}
 	}
 
+	if len(o.ACMEIssuerConditionReasonPrefix) > 0 && !conditionReasonPrefixRegexp.MatchString(o.ACMEIssuerConditionReasonPrefix) {
+		return fmt.Errorf("invalid value for acme-issuer-condition-reason-prefix: %q must match the regex %q",
+			o.ACMEIssuerConditionReasonPrefix, conditionReasonPrefixRegexp.String())
+	}
+
+	if o.ACMEIssuerClockSkewThreshold < 0 {
+		return fmt.Errorf("invalid value for acme-issuer-clock-skew-threshold: %s must not be negative", o.ACMEIssuerClockSkewThreshold)
+	}
+
+	if o.DefaultRevisionHistoryLimit < 0 {
+		return fmt.Errorf("invalid value for default-revision-history-limit: %d must not be negative", o.DefaultRevisionHistoryLimit)
+	}
+
+	if len(o.ACMEHTTP01SelfCheckAddress) > 0 {
+		if _, _, err := net.SplitHostPort(o.ACMEHTTP01SelfCheckAddress); err != nil {
+			return fmt.Errorf("invalid value for acme-http01-self-check-address (%v): %v", err, o.ACMEHTTP01SelfCheckAddress)
+		}
+	}
+
+	if o.ACMEOrderTimeout < 0 {
+		return fmt.Errorf("invalid value for acme-order-timeout: %s must not be negative", o.ACMEOrderTimeout)
+	}
+
+	if o.ACMEIssuerHTTPTimeout <= 0 {
+		return fmt.Errorf("invalid value for acme-issuer-http-timeout: %s must be positive", o.ACMEIssuerHTTPTimeout)
+	}
+
+	if o.ACMEIssuerBootstrapGracePeriod < 0 {
+		return fmt.Errorf("invalid value for acme-issuer-bootstrap-grace-period: %s must not be negative", o.ACMEIssuerBootstrapGracePeriod)
+	}
+
+	if o.ACMEIssuerCorrelationID && !headerNameRegexp.MatchString(o.ACMEIssuerCorrelationIDHeader) {
+		return fmt.Errorf("invalid value for acme-issuer-correlation-id-header: %q must match the regex %q",
+			o.ACMEIssuerCorrelationIDHeader, headerNameRegexp.String())
+	}
+
 	errs := []error{}
 	allControllersSet := sets.NewString(allControllers...)
 	for _, controller := range o.controllers {
@@ -0,0 +1,129 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/server/healthz"
+	"k8s.io/client-go/kubernetes"
+
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// secretVerbs are the verbs on Secrets that the controller needs to issue
+// certificates and to manage ACME account private keys.
+var secretVerbs = []string{"get", "list", "watch", "create", "update", "delete"}
+
+// secretPermissionsCheck is a readiness check that fails until the
+// controller's permissions on Secrets have been checked, and afterwards if any
+// of the permissions in secretVerbs are missing.
+type secretPermissionsCheck struct {
+	lock    sync.Mutex
+	checked bool
+	err     error
+}
+
+var _ healthz.HealthChecker = &secretPermissionsCheck{}
+
+func (c *secretPermissionsCheck) Name() string {
+	return "secret-permissions"
+}
+
+func (c *secretPermissionsCheck) Check(_ *http.Request) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if !c.checked {
+		return errors.New("the permissions on Secrets have not been checked yet")
+	}
+	return c.err
+}
+
+// run checks the controller's permissions on Secrets in the given namespaces
+// and records the result, see checkSecretPermissions.
+func (c *secretPermissionsCheck) run(ctx context.Context, cl kubernetes.Interface, namespaces []string) {
+	err := checkSecretPermissions(ctx, cl, namespaces)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.checked = true
+	c.err = err
+}
+
+// checkSecretPermissions uses SelfSubjectAccessReviews to check that the
+// controller is allowed to perform every verb in secretVerbs on Secrets in each
+// of the given namespaces. An empty namespace checks for permissions across all
+// namespaces. An error listing the missing permissions is returned, so that a
+// common RBAC misconfiguration is reported at startup rather than when a
+// Secret is first written, e.g. when an ACME account private key is created.
+// If the permissions cannot be checked, no error is returned.
+func checkSecretPermissions(ctx context.Context, cl kubernetes.Interface, namespaces []string) error {
+	log := logf.FromContext(ctx, "secret-permissions-check")
+
+	var missing []string
+	for _, namespace := range sets.NewString(namespaces...).List() {
+		var missingVerbs []string
+		for _, verb := range secretVerbs {
+			review := &authorizationv1.SelfSubjectAccessReview{
+				Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+					ResourceAttributes: &authorizationv1.ResourceAttributes{
+						Namespace: namespace,
+						Verb:      verb,
+						Resource:  "secrets",
+					},
+				},
+			}
+
+			resp, err := cl.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+			if err != nil {
+				log.V(logf.DebugLevel).Info("skipping Secret permissions check as a SelfSubjectAccessReview could not be created", "error", err.Error())
+				return nil
+			}
+
+			if !resp.Status.Allowed {
+				log.Error(nil, "the controller is not allowed to perform a required operation on Secrets, "+
+					"certificate issuance and ACME account registration will fail until its RBAC is fixed",
+					"verb", verb, "namespace", namespace, "reason", resp.Status.Reason)
+				missingVerbs = append(missingVerbs, verb)
+			}
+		}
+
+		if len(missingVerbs) == 0 {
+			continue
+		}
+		scope := fmt.Sprintf("in namespace %q", namespace)
+		if namespace == "" {
+			scope = "in all namespaces"
+		}
+		missing = append(missing, fmt.Sprintf("%s Secrets %s", strings.Join(missingVerbs, ", "), scope))
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("the controller is not allowed to %s", strings.Join(missing, "; "))
+	}
+
+	log.V(logf.InfoLevel).Info("the controller has all required permissions on Secrets", "namespaces", namespaces)
+	return nil
+}
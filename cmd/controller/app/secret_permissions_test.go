@@ -0,0 +1,90 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	coretesting "k8s.io/client-go/testing"
+)
+
+func TestSecretPermissionsCheck(t *testing.T) {
+	tests := map[string]struct {
+		namespaces []string
+		// denied is the set of "namespace/verb" pairs that are not allowed.
+		denied    map[string]bool
+		reviewErr error
+		expErr    string
+	}{
+		"all permissions are allowed": {
+			namespaces: []string{"cert-manager"},
+			expErr:     "",
+		},
+		"missing permissions fail the check and are listed": {
+			namespaces: []string{"", "cert-manager"},
+			denied: map[string]bool{
+				"/create":             true,
+				"cert-manager/update": true,
+				"cert-manager/delete": true,
+			},
+			expErr: `the controller is not allowed to create Secrets in all namespaces; update, delete Secrets in namespace "cert-manager"`,
+		},
+		"the check passes if the permissions cannot be checked": {
+			namespaces: []string{"cert-manager"},
+			reviewErr:  errors.New("forbidden"),
+			expErr:     "",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cl := fake.NewSimpleClientset()
+			cl.PrependReactor("create", "selfsubjectaccessreviews", func(action coretesting.Action) (bool, runtime.Object, error) {
+				if test.reviewErr != nil {
+					return true, nil, test.reviewErr
+				}
+				review := action.(coretesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+				attrs := review.Spec.ResourceAttributes
+				if attrs.Resource != "secrets" {
+					t.Errorf("unexpected resource in SelfSubjectAccessReview: %q", attrs.Resource)
+				}
+				review.Status.Allowed = !test.denied[attrs.Namespace+"/"+attrs.Verb]
+				return true, review, nil
+			})
+
+			check := &secretPermissionsCheck{}
+			if err := check.Check(nil); err == nil {
+				t.Errorf("expected the check to fail before the permissions have been checked")
+			}
+
+			check.run(context.Background(), cl, test.namespaces)
+
+			err := check.Check(nil)
+			if test.expErr == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if test.expErr != "" && (err == nil || err.Error() != test.expErr) {
+				t.Errorf("unexpected error, exp=%q got=%v", test.expErr, err)
+			}
+		})
+	}
+}
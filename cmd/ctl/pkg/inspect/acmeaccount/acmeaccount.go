@@ -0,0 +1,124 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acmeaccount
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/cert-manager/cert-manager/cmctl-binary/pkg/build"
+	"github.com/cert-manager/cert-manager/cmctl-binary/pkg/factory"
+	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Get a JSON report of the ACME account registration of an Issuer or ClusterIssuer.
+
+The report is derived from the issuer's spec and status only, and contains a
+hash of the ACME account URI instead of the URI itself.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Report the ACME account registration of the Issuer 'letsencrypt' in namespace 'my-namespace'
+{{.BuildName}} inspect acme-account letsencrypt --namespace my-namespace
+
+# Report the ACME account registration of the ClusterIssuer 'letsencrypt'
+{{.BuildName}} inspect acme-account letsencrypt --cluster-issuer
+`)))
+)
+
+// Options is a struct to support inspect acme-account command
+type Options struct {
+	// ClusterIssuer is true if the named issuer is a ClusterIssuer
+	ClusterIssuer bool
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdInspectACMEAccount returns a cobra command for inspect acme-account
+func NewCmdInspectACMEAccount(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "acme-account",
+		Short:   "Get a JSON report of the ACME account registration of an issuer",
+		Long:    long,
+		Example: example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate(args))
+			cmdutil.CheckErr(o.Run(ctx, args))
+		},
+	}
+
+	cmd.Flags().BoolVar(&o.ClusterIssuer, "cluster-issuer", o.ClusterIssuer, "If present, the named issuer is a ClusterIssuer rather than an Issuer.")
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) < 1 {
+		return errors.New("the name of the issuer has to be provided as argument")
+	}
+	if len(args) > 1 {
+		return errors.New("only one argument can be passed in: the name of the issuer")
+	}
+	return nil
+}
+
+// Run executes inspect acme-account command
+func (o *Options) Run(ctx context.Context, args []string) error {
+	var issuer cmapi.GenericIssuer
+	var err error
+	if o.ClusterIssuer {
+		issuer, err = o.CMClient.CertmanagerV1().ClusterIssuers().Get(ctx, args[0], metav1.GetOptions{})
+	} else {
+		issuer, err = o.CMClient.CertmanagerV1().Issuers(o.Namespace).Get(ctx, args[0], metav1.GetOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("error when getting issuer %q: %w", args[0], err)
+	}
+
+	report, err := accounts.NewRegistrationReport(issuer)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(o.Out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acmeaccount
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/cert-manager/cert-manager/cmctl-binary/pkg/factory"
+	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		args      []string
+		expErr    bool
+		expErrMsg string
+	}{
+		"issuer name not passed as arg throws error": {
+			args:      []string{},
+			expErr:    true,
+			expErrMsg: "the name of the issuer has to be provided as argument",
+		},
+		"multiple issuer names passed as arg throws error": {
+			args:      []string{"issuer-1", "issuer-2"},
+			expErr:    true,
+			expErrMsg: "only one argument can be passed in: the name of the issuer",
+		},
+		"a single issuer name should not error": {
+			args:   []string{"issuer-1"},
+			expErr: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			opts := &Options{}
+
+			err := opts.Validate(test.args)
+			if (err != nil) != test.expErr {
+				t.Errorf("unexpected error, exp=%t got=%v",
+					test.expErr, err)
+			}
+			if err != nil && err.Error() != test.expErrMsg {
+				t.Errorf("got unexpected error when validating args, expected: %v; actual: %v", test.expErrMsg, err)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	const (
+		accountURI    = "https://acme.example.com/acme/acct/12345"
+		keyThumbprint = "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs"
+	)
+
+	acmeStatus := func(iss cmapi.GenericIssuer) {
+		iss.GetStatus().ACMEStatus().AccountKeyThumbprint = keyThumbprint
+	}
+	issuer := gen.Issuer("letsencrypt",
+		gen.SetIssuerNamespace("my-namespace"),
+		gen.SetIssuerACME(cmacme.ACMEIssuer{Server: "https://acme.example.com/directory"}),
+		gen.SetIssuerACMEAccountURL(accountURI),
+		acmeStatus,
+	)
+	clusterIssuer := gen.ClusterIssuer("letsencrypt",
+		gen.SetIssuerACME(cmacme.ACMEIssuer{Server: "https://acme.example.com/directory"}),
+		gen.SetIssuerACMEAccountURL(accountURI),
+		acmeStatus,
+	)
+	selfSigned := gen.Issuer("self-signed",
+		gen.SetIssuerNamespace("my-namespace"),
+		gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{}),
+	)
+
+	tests := map[string]struct {
+		clusterIssuer bool
+		name          string
+		expKind       string
+		expNamespace  string
+		expErr        bool
+	}{
+		"the report of an Issuer is printed": {
+			name:         "letsencrypt",
+			expKind:      cmapi.IssuerKind,
+			expNamespace: "my-namespace",
+		},
+		"the report of a ClusterIssuer is printed": {
+			clusterIssuer: true,
+			name:          "letsencrypt",
+			expKind:       cmapi.ClusterIssuerKind,
+		},
+		"an issuer that does not exist throws error": {
+			name:   "does-not-exist",
+			expErr: true,
+		},
+		"an issuer that is not an ACME issuer throws error": {
+			name:   "self-signed",
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			opts := &Options{
+				ClusterIssuer: test.clusterIssuer,
+				IOStreams:     streams,
+				Factory: &factory.Factory{
+					Namespace: "my-namespace",
+					CMClient:  cmfake.NewSimpleClientset(issuer, clusterIssuer, selfSigned),
+				},
+			}
+
+			err := opts.Run(context.TODO(), []string{test.name})
+			if (err != nil) != test.expErr {
+				t.Fatalf("unexpected error, exp=%t got=%v", test.expErr, err)
+			}
+			if test.expErr {
+				return
+			}
+
+			if strings.Contains(out.String(), accountURI) {
+				t.Errorf("report must not contain the raw account URI: %s", out)
+			}
+
+			var report accounts.RegistrationReport
+			if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+				t.Fatalf("failed to decode report: %v", err)
+			}
+			if report.Kind != test.expKind || report.Namespace != test.expNamespace || report.Name != test.name {
+				t.Errorf("unexpected issuer in report, exp=%s %s/%s got=%s %s/%s",
+					test.expKind, test.expNamespace, test.name, report.Kind, report.Namespace, report.Name)
+			}
+			if report.KeyThumbprint != keyThumbprint {
+				t.Errorf("unexpected key thumbprint in report: %q", report.KeyThumbprint)
+			}
+		})
+	}
+}
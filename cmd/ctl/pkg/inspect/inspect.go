@@ -22,6 +22,7 @@ import (
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
+	"github.com/cert-manager/cert-manager/cmctl-binary/pkg/inspect/acmeaccount"
 	"github.com/cert-manager/cert-manager/cmctl-binary/pkg/inspect/secret"
 )
 
@@ -29,10 +30,11 @@ func NewCmdInspect(ctx context.Context, ioStreams genericclioptions.IOStreams) *
 	cmds := &cobra.Command{
 		Use:   "inspect",
 		Short: "Get details on certificate related resources",
-		Long:  `Get details on certificate related resources, e.g. secrets`,
+		Long:  `Get details on certificate related resources, e.g. secrets and ACME accounts`,
 	}
 
 	cmds.AddCommand(secret.NewCmdInspectSecret(ctx, ioStreams))
+	cmds.AddCommand(acmeaccount.NewCmdInspectACMEAccount(ctx, ioStreams))
 
 	return cmds
 }
@@ -36,6 +36,7 @@ require (
 	golang.org/x/crypto v0.5.0
 	golang.org/x/oauth2 v0.5.0
 	golang.org/x/sync v0.1.0
+	golang.org/x/text v0.7.0
 	gomodules.xyz/jsonpatch/v2 v2.2.0
 	google.golang.org/api v0.111.0
 	k8s.io/api v0.26.3
@@ -175,7 +176,6 @@ require (
 	golang.org/x/net v0.7.0 // indirect
 	golang.org/x/sys v0.5.0 // indirect
 	golang.org/x/term v0.5.0 // indirect
-	golang.org/x/text v0.7.0 // indirect
 	golang.org/x/time v0.3.0 // indirect
 	golang.org/x/tools v0.6.0 // indirect
 	google.golang.org/appengine v1.6.7 // indirect
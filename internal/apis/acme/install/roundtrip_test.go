@@ -18,12 +18,53 @@ package install
 
 import (
 	"testing"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/apitesting/roundtrip"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
+	"github.com/cert-manager/cert-manager/internal/apis/acme"
 	acmefuzzer "github.com/cert-manager/cert-manager/internal/apis/acme/fuzzer"
+	"github.com/cert-manager/cert-manager/internal/apis/acme/v1alpha2"
+	"github.com/cert-manager/cert-manager/internal/apis/acme/v1alpha3"
+	"github.com/cert-manager/cert-manager/internal/apis/acme/v1beta1"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 )
 
 func TestRoundTripTypes(t *testing.T) {
 	roundtrip.RoundTripTestForAPIGroup(t, Install, acmefuzzer.Funcs)
 }
+
+func TestOrderRateLimitedUntilConversion(t *testing.T) {
+	scheme := runtime.NewScheme()
+	Install(scheme)
+
+	rateLimitedUntil := metav1.NewTime(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	in := &cmacme.Order{
+		Status: cmacme.OrderStatus{RateLimitedUntil: &rateLimitedUntil},
+	}
+
+	for _, version := range []runtime.Object{&v1alpha2.Order{}, &v1alpha3.Order{}, &v1beta1.Order{}, &cmacme.Order{}} {
+		hub := &acme.Order{}
+		if err := scheme.Convert(in, hub, nil); err != nil {
+			t.Fatal(err)
+		}
+		if err := scheme.Convert(hub, version, nil); err != nil {
+			t.Fatal(err)
+		}
+		hub = &acme.Order{}
+		if err := scheme.Convert(version, hub, nil); err != nil {
+			t.Fatal(err)
+		}
+		out := &cmacme.Order{}
+		if err := scheme.Convert(hub, out, nil); err != nil {
+			t.Fatal(err)
+		}
+
+		if !apiequality.Semantic.DeepEqual(in, out) {
+			t.Errorf("rateLimitedUntil was not preserved when converting through %T, exp=%v got=%v", version, in.Status.RateLimitedUntil, out.Status.RateLimitedUntil)
+		}
+	}
+}
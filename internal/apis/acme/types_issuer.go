@@ -19,6 +19,7 @@ package acme
 import (
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	gwapi "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	cmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
@@ -49,6 +50,14 @@ type ACMEIssuer struct {
 	// "DST Root CA X3" or "ISRG Root X1" for the newer Let's Encrypt root CA.
 	PreferredChain string
 
+	// PreferredLanguage is the language tag, as defined in BCP 47 (for
+	// example "en", "de" or "pt-BR"), that is sent to the ACME server in the
+	// Accept-Language header of every request. ACME servers that support it
+	// will return localized problem detail messages in this language.
+	// If not set, no Accept-Language header is sent and the ACME server's
+	// default language is used.
+	PreferredLanguage string
+
 	// Base64-encoded bundle of PEM CAs which can be used to validate the certificate
 	// chain presented by the ACME server.
 	// Mutually exclusive with SkipTLSVerify; prefer using CABundle to prevent various
@@ -96,6 +105,24 @@ type ACMEIssuer struct {
 	// Defaults to false.
 	DisableAccountKeyGeneration bool
 
+	// RequireEmail determines whether an email address must be configured
+	// for the ACME account. If true and Email is not set, the Issuer will not
+	// become ready and no ACME account will be registered.
+	// If false, an ACME account without a contact email is registered when
+	// Email is not set.
+	// Defaults to false.
+	RequireEmail bool
+
+	// AcceptedTermsOfServiceURL is the URL of the ACME server's terms of
+	// service that the issuer explicitly agrees to. If set, an ACME account is
+	// only registered or verified if the terms of service published in the
+	// ACME server's directory are at this URL, so that new terms of service
+	// are not agreed to without review.
+	// If not set, the ACME server's current terms of service are agreed to
+	// automatically. This field is ignored for ACME servers that do not
+	// publish terms of service.
+	AcceptedTermsOfServiceURL string
+
 	// Enables requesting a Not After date on certificates that matches the
 	// duration of the certificate. This is not supported by all ACME servers
 	// like Let's Encrypt. If set to true when the ACME server does not support
@@ -557,4 +584,45 @@ type ACMEIssuerStatus struct {
 	// ACME account, in order to track changes made to registered account
 	// associated with the  Issuer
 	LastRegisteredEmail string
+
+	// AccountKeyParameters records the parameters that were used to generate
+	// the ACME account private key. It is only set when cert-manager generates
+	// the account key, and is left unchanged when an existing key is used.
+	AccountKeyParameters *ACMEAccountKeyParameters
+
+	// RegisteredAt is the time at which the ACME account was newly created
+	// by cert-manager. It is not set if cert-manager found an account that
+	// already existed on the ACME server for the account private key.
+	RegisteredAt *metav1.Time
+
+	// TermsOfServiceURL is the URL of the ACME server's terms of service that
+	// were in effect when the ACME account was registered, or when it was
+	// first verified by cert-manager.
+	TermsOfServiceURL string
+
+	// AccountKeyThumbprint is the base64url encoded SHA-256 JWK thumbprint, as
+	// defined in RFC 7638, of the public key of the ACME account. It is set
+	// when the account is registered or verified with the ACME server.
+	AccountKeyThumbprint string
+
+	// LastVerifiedAt is the time at which the ACME account was last
+	// registered or verified with the ACME server.
+	LastVerifiedAt *metav1.Time
+}
+
+// ACMEAccountKeyParameters describes how an ACME account private key was
+// generated by cert-manager.
+type ACMEAccountKeyParameters struct {
+	// Algorithm is the private key algorithm of the account key, e.g. `RSA`.
+	Algorithm string
+
+	// Size is the size of the account key in bits.
+	Size int
+
+	// Encoding is the encoding used to store the account key in the Secret,
+	// e.g. `PKCS1`.
+	Encoding string
+
+	// PublicExponent is the public exponent of an RSA account key.
+	PublicExponent int
 }
@@ -109,6 +109,11 @@ type OrderStatus struct {
 	// FailureTime stores the time that this order failed.
 	// This is used to influence garbage collection and back-off.
 	FailureTime *metav1.Time
+
+	// RateLimitedUntil stores the time until which the ACME server asked for
+	// this order not to be retried after rejecting it due to a rate limit.
+	// The order will not be submitted to the ACME server again before then.
+	RateLimitedUntil *metav1.Time
 }
 
 // ACMEAuthorization contains data returned from the ACME server on an
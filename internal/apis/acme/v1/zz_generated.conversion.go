@@ -44,6 +44,16 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEAccountKeyParameters)(nil), (*acme.ACMEAccountKeyParameters)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEAccountKeyParameters_To_acme_ACMEAccountKeyParameters(a.(*v1.ACMEAccountKeyParameters), b.(*acme.ACMEAccountKeyParameters), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEAccountKeyParameters)(nil), (*v1.ACMEAccountKeyParameters)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEAccountKeyParameters_To_v1_ACMEAccountKeyParameters(a.(*acme.ACMEAccountKeyParameters), b.(*v1.ACMEAccountKeyParameters), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.ACMEAuthorization)(nil), (*acme.ACMEAuthorization)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_ACMEAuthorization_To_acme_ACMEAuthorization(a.(*v1.ACMEAuthorization), b.(*acme.ACMEAuthorization), scope)
 	}); err != nil {
@@ -387,6 +397,32 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1_ACMEAccountKeyParameters_To_acme_ACMEAccountKeyParameters(in *v1.ACMEAccountKeyParameters, out *acme.ACMEAccountKeyParameters, s conversion.Scope) error {
+	out.Algorithm = in.Algorithm
+	out.Size = in.Size
+	out.Encoding = in.Encoding
+	out.PublicExponent = in.PublicExponent
+	return nil
+}
+
+// Convert_v1_ACMEAccountKeyParameters_To_acme_ACMEAccountKeyParameters is an autogenerated conversion function.
+func Convert_v1_ACMEAccountKeyParameters_To_acme_ACMEAccountKeyParameters(in *v1.ACMEAccountKeyParameters, out *acme.ACMEAccountKeyParameters, s conversion.Scope) error {
+	return autoConvert_v1_ACMEAccountKeyParameters_To_acme_ACMEAccountKeyParameters(in, out, s)
+}
+
+func autoConvert_acme_ACMEAccountKeyParameters_To_v1_ACMEAccountKeyParameters(in *acme.ACMEAccountKeyParameters, out *v1.ACMEAccountKeyParameters, s conversion.Scope) error {
+	out.Algorithm = in.Algorithm
+	out.Size = in.Size
+	out.Encoding = in.Encoding
+	out.PublicExponent = in.PublicExponent
+	return nil
+}
+
+// Convert_acme_ACMEAccountKeyParameters_To_v1_ACMEAccountKeyParameters is an autogenerated conversion function.
+func Convert_acme_ACMEAccountKeyParameters_To_v1_ACMEAccountKeyParameters(in *acme.ACMEAccountKeyParameters, out *v1.ACMEAccountKeyParameters, s conversion.Scope) error {
+	return autoConvert_acme_ACMEAccountKeyParameters_To_v1_ACMEAccountKeyParameters(in, out, s)
+}
+
 func autoConvert_v1_ACMEAuthorization_To_acme_ACMEAuthorization(in *v1.ACMEAuthorization, out *acme.ACMEAuthorization, s conversion.Scope) error {
 	out.URL = in.URL
 	out.Identifier = in.Identifier
@@ -881,6 +917,7 @@ func autoConvert_v1_ACMEIssuer_To_acme_ACMEIssuer(in *v1.ACMEIssuer, out *acme.A
 	out.Email = in.Email
 	out.Server = in.Server
 	out.PreferredChain = in.PreferredChain
+	out.PreferredLanguage = in.PreferredLanguage
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
 	out.SkipTLSVerify = in.SkipTLSVerify
 	if in.ExternalAccountBinding != nil {
@@ -907,6 +944,8 @@ func autoConvert_v1_ACMEIssuer_To_acme_ACMEIssuer(in *v1.ACMEIssuer, out *acme.A
 		out.Solvers = nil
 	}
 	out.DisableAccountKeyGeneration = in.DisableAccountKeyGeneration
+	out.RequireEmail = in.RequireEmail
+	out.AcceptedTermsOfServiceURL = in.AcceptedTermsOfServiceURL
 	out.EnableDurationFeature = in.EnableDurationFeature
 	return nil
 }
@@ -915,6 +954,7 @@ func autoConvert_acme_ACMEIssuer_To_v1_ACMEIssuer(in *acme.ACMEIssuer, out *v1.A
 	out.Email = in.Email
 	out.Server = in.Server
 	out.PreferredChain = in.PreferredChain
+	out.PreferredLanguage = in.PreferredLanguage
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
 	out.SkipTLSVerify = in.SkipTLSVerify
 	if in.ExternalAccountBinding != nil {
@@ -941,6 +981,8 @@ func autoConvert_acme_ACMEIssuer_To_v1_ACMEIssuer(in *acme.ACMEIssuer, out *v1.A
 		out.Solvers = nil
 	}
 	out.DisableAccountKeyGeneration = in.DisableAccountKeyGeneration
+	out.RequireEmail = in.RequireEmail
+	out.AcceptedTermsOfServiceURL = in.AcceptedTermsOfServiceURL
 	out.EnableDurationFeature = in.EnableDurationFeature
 	return nil
 }
@@ -1286,6 +1328,11 @@ func Convert_acme_ACMEIssuerDNS01ProviderWebhook_To_v1_ACMEIssuerDNS01ProviderWe
 func autoConvert_v1_ACMEIssuerStatus_To_acme_ACMEIssuerStatus(in *v1.ACMEIssuerStatus, out *acme.ACMEIssuerStatus, s conversion.Scope) error {
 	out.URI = in.URI
 	out.LastRegisteredEmail = in.LastRegisteredEmail
+	out.AccountKeyParameters = (*acme.ACMEAccountKeyParameters)(unsafe.Pointer(in.AccountKeyParameters))
+	out.RegisteredAt = (*pkgapismetav1.Time)(unsafe.Pointer(in.RegisteredAt))
+	out.TermsOfServiceURL = in.TermsOfServiceURL
+	out.AccountKeyThumbprint = in.AccountKeyThumbprint
+	out.LastVerifiedAt = (*pkgapismetav1.Time)(unsafe.Pointer(in.LastVerifiedAt))
 	return nil
 }
 
@@ -1297,6 +1344,11 @@ func Convert_v1_ACMEIssuerStatus_To_acme_ACMEIssuerStatus(in *v1.ACMEIssuerStatu
 func autoConvert_acme_ACMEIssuerStatus_To_v1_ACMEIssuerStatus(in *acme.ACMEIssuerStatus, out *v1.ACMEIssuerStatus, s conversion.Scope) error {
 	out.URI = in.URI
 	out.LastRegisteredEmail = in.LastRegisteredEmail
+	out.AccountKeyParameters = (*v1.ACMEAccountKeyParameters)(unsafe.Pointer(in.AccountKeyParameters))
+	out.RegisteredAt = (*pkgapismetav1.Time)(unsafe.Pointer(in.RegisteredAt))
+	out.TermsOfServiceURL = in.TermsOfServiceURL
+	out.AccountKeyThumbprint = in.AccountKeyThumbprint
+	out.LastVerifiedAt = (*pkgapismetav1.Time)(unsafe.Pointer(in.LastVerifiedAt))
 	return nil
 }
 
@@ -1611,6 +1663,7 @@ func autoConvert_v1_OrderStatus_To_acme_OrderStatus(in *v1.OrderStatus, out *acm
 	out.State = acme.State(in.State)
 	out.Reason = in.Reason
 	out.FailureTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.RateLimitedUntil = (*pkgapismetav1.Time)(unsafe.Pointer(in.RateLimitedUntil))
 	return nil
 }
 
@@ -1627,6 +1680,7 @@ func autoConvert_acme_OrderStatus_To_v1_OrderStatus(in *acme.OrderStatus, out *v
 	out.Reason = in.Reason
 	out.Authorizations = *(*[]v1.ACMEAuthorization)(unsafe.Pointer(&in.Authorizations))
 	out.FailureTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.RateLimitedUntil = (*pkgapismetav1.Time)(unsafe.Pointer(in.RateLimitedUntil))
 	return nil
 }
 
@@ -43,6 +43,16 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*ACMEAccountKeyParameters)(nil), (*acme.ACMEAccountKeyParameters)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ACMEAccountKeyParameters_To_acme_ACMEAccountKeyParameters(a.(*ACMEAccountKeyParameters), b.(*acme.ACMEAccountKeyParameters), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEAccountKeyParameters)(nil), (*ACMEAccountKeyParameters)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEAccountKeyParameters_To_v1alpha2_ACMEAccountKeyParameters(a.(*acme.ACMEAccountKeyParameters), b.(*ACMEAccountKeyParameters), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ACMEAuthorization)(nil), (*acme.ACMEAuthorization)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_ACMEAuthorization_To_acme_ACMEAuthorization(a.(*ACMEAuthorization), b.(*acme.ACMEAuthorization), scope)
 	}); err != nil {
@@ -386,6 +396,32 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1alpha2_ACMEAccountKeyParameters_To_acme_ACMEAccountKeyParameters(in *ACMEAccountKeyParameters, out *acme.ACMEAccountKeyParameters, s conversion.Scope) error {
+	out.Algorithm = in.Algorithm
+	out.Size = in.Size
+	out.Encoding = in.Encoding
+	out.PublicExponent = in.PublicExponent
+	return nil
+}
+
+// Convert_v1alpha2_ACMEAccountKeyParameters_To_acme_ACMEAccountKeyParameters is an autogenerated conversion function.
+func Convert_v1alpha2_ACMEAccountKeyParameters_To_acme_ACMEAccountKeyParameters(in *ACMEAccountKeyParameters, out *acme.ACMEAccountKeyParameters, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ACMEAccountKeyParameters_To_acme_ACMEAccountKeyParameters(in, out, s)
+}
+
+func autoConvert_acme_ACMEAccountKeyParameters_To_v1alpha2_ACMEAccountKeyParameters(in *acme.ACMEAccountKeyParameters, out *ACMEAccountKeyParameters, s conversion.Scope) error {
+	out.Algorithm = in.Algorithm
+	out.Size = in.Size
+	out.Encoding = in.Encoding
+	out.PublicExponent = in.PublicExponent
+	return nil
+}
+
+// Convert_acme_ACMEAccountKeyParameters_To_v1alpha2_ACMEAccountKeyParameters is an autogenerated conversion function.
+func Convert_acme_ACMEAccountKeyParameters_To_v1alpha2_ACMEAccountKeyParameters(in *acme.ACMEAccountKeyParameters, out *ACMEAccountKeyParameters, s conversion.Scope) error {
+	return autoConvert_acme_ACMEAccountKeyParameters_To_v1alpha2_ACMEAccountKeyParameters(in, out, s)
+}
+
 func autoConvert_v1alpha2_ACMEAuthorization_To_acme_ACMEAuthorization(in *ACMEAuthorization, out *acme.ACMEAuthorization, s conversion.Scope) error {
 	out.URL = in.URL
 	out.Identifier = in.Identifier
@@ -880,6 +916,7 @@ func autoConvert_v1alpha2_ACMEIssuer_To_acme_ACMEIssuer(in *ACMEIssuer, out *acm
 	out.Email = in.Email
 	out.Server = in.Server
 	out.PreferredChain = in.PreferredChain
+	out.PreferredLanguage = in.PreferredLanguage
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
 	out.SkipTLSVerify = in.SkipTLSVerify
 	if in.ExternalAccountBinding != nil {
@@ -906,6 +943,8 @@ func autoConvert_v1alpha2_ACMEIssuer_To_acme_ACMEIssuer(in *ACMEIssuer, out *acm
 		out.Solvers = nil
 	}
 	out.DisableAccountKeyGeneration = in.DisableAccountKeyGeneration
+	out.RequireEmail = in.RequireEmail
+	out.AcceptedTermsOfServiceURL = in.AcceptedTermsOfServiceURL
 	out.EnableDurationFeature = in.EnableDurationFeature
 	return nil
 }
@@ -914,6 +953,7 @@ func autoConvert_acme_ACMEIssuer_To_v1alpha2_ACMEIssuer(in *acme.ACMEIssuer, out
 	out.Email = in.Email
 	out.Server = in.Server
 	out.PreferredChain = in.PreferredChain
+	out.PreferredLanguage = in.PreferredLanguage
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
 	out.SkipTLSVerify = in.SkipTLSVerify
 	if in.ExternalAccountBinding != nil {
@@ -940,6 +980,8 @@ func autoConvert_acme_ACMEIssuer_To_v1alpha2_ACMEIssuer(in *acme.ACMEIssuer, out
 		out.Solvers = nil
 	}
 	out.DisableAccountKeyGeneration = in.DisableAccountKeyGeneration
+	out.RequireEmail = in.RequireEmail
+	out.AcceptedTermsOfServiceURL = in.AcceptedTermsOfServiceURL
 	out.EnableDurationFeature = in.EnableDurationFeature
 	return nil
 }
@@ -1285,6 +1327,11 @@ func Convert_acme_ACMEIssuerDNS01ProviderWebhook_To_v1alpha2_ACMEIssuerDNS01Prov
 func autoConvert_v1alpha2_ACMEIssuerStatus_To_acme_ACMEIssuerStatus(in *ACMEIssuerStatus, out *acme.ACMEIssuerStatus, s conversion.Scope) error {
 	out.URI = in.URI
 	out.LastRegisteredEmail = in.LastRegisteredEmail
+	out.AccountKeyParameters = (*acme.ACMEAccountKeyParameters)(unsafe.Pointer(in.AccountKeyParameters))
+	out.RegisteredAt = (*pkgapismetav1.Time)(unsafe.Pointer(in.RegisteredAt))
+	out.TermsOfServiceURL = in.TermsOfServiceURL
+	out.AccountKeyThumbprint = in.AccountKeyThumbprint
+	out.LastVerifiedAt = (*pkgapismetav1.Time)(unsafe.Pointer(in.LastVerifiedAt))
 	return nil
 }
 
@@ -1296,6 +1343,11 @@ func Convert_v1alpha2_ACMEIssuerStatus_To_acme_ACMEIssuerStatus(in *ACMEIssuerSt
 func autoConvert_acme_ACMEIssuerStatus_To_v1alpha2_ACMEIssuerStatus(in *acme.ACMEIssuerStatus, out *ACMEIssuerStatus, s conversion.Scope) error {
 	out.URI = in.URI
 	out.LastRegisteredEmail = in.LastRegisteredEmail
+	out.AccountKeyParameters = (*ACMEAccountKeyParameters)(unsafe.Pointer(in.AccountKeyParameters))
+	out.RegisteredAt = (*pkgapismetav1.Time)(unsafe.Pointer(in.RegisteredAt))
+	out.TermsOfServiceURL = in.TermsOfServiceURL
+	out.AccountKeyThumbprint = in.AccountKeyThumbprint
+	out.LastVerifiedAt = (*pkgapismetav1.Time)(unsafe.Pointer(in.LastVerifiedAt))
 	return nil
 }
 
@@ -1590,6 +1642,7 @@ func autoConvert_v1alpha2_OrderStatus_To_acme_OrderStatus(in *OrderStatus, out *
 	out.State = acme.State(in.State)
 	out.Reason = in.Reason
 	out.FailureTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.RateLimitedUntil = (*pkgapismetav1.Time)(unsafe.Pointer(in.RateLimitedUntil))
 	return nil
 }
 
@@ -1606,6 +1659,7 @@ func autoConvert_acme_OrderStatus_To_v1alpha2_OrderStatus(in *acme.OrderStatus,
 	out.Reason = in.Reason
 	out.Authorizations = *(*[]ACMEAuthorization)(unsafe.Pointer(&in.Authorizations))
 	out.FailureTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.RateLimitedUntil = (*pkgapismetav1.Time)(unsafe.Pointer(in.RateLimitedUntil))
 	return nil
 }
 
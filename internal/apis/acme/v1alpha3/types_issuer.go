@@ -19,6 +19,7 @@ package v1alpha3
 import (
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	gwapi "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
@@ -54,6 +55,15 @@ type ACMEIssuer struct {
 	// +kubebuilder:validation:MaxLength=64
 	PreferredChain string `json:"preferredChain"`
 
+	// PreferredLanguage is the language tag, as defined in BCP 47 (for
+	// example "en", "de" or "pt-BR"), that is sent to the ACME server in the
+	// Accept-Language header of every request. ACME servers that support it
+	// will return localized problem detail messages in this language.
+	// If not set, no Accept-Language header is sent and the ACME server's
+	// default language is used.
+	// +optional
+	PreferredLanguage string `json:"preferredLanguage,omitempty"`
+
 	// Base64-encoded bundle of PEM CAs which can be used to validate the certificate
 	// chain presented by the ACME server.
 	// Mutually exclusive with SkipTLSVerify; prefer using CABundle to prevent various
@@ -106,6 +116,26 @@ type ACMEIssuer struct {
 	// +optional
 	DisableAccountKeyGeneration bool `json:"disableAccountKeyGeneration,omitempty"`
 
+	// RequireEmail determines whether an email address must be configured
+	// for the ACME account. If true and Email is not set, the Issuer will not
+	// become ready and no ACME account will be registered.
+	// If false, an ACME account without a contact email is registered when
+	// Email is not set.
+	// Defaults to false.
+	// +optional
+	RequireEmail bool `json:"requireEmail,omitempty"`
+
+	// AcceptedTermsOfServiceURL is the URL of the ACME server's terms of
+	// service that the issuer explicitly agrees to. If set, an ACME account is
+	// only registered or verified if the terms of service published in the
+	// ACME server's directory are at this URL, so that new terms of service
+	// are not agreed to without review.
+	// If not set, the ACME server's current terms of service are agreed to
+	// automatically. This field is ignored for ACME servers that do not
+	// publish terms of service.
+	// +optional
+	AcceptedTermsOfServiceURL string `json:"acceptedTermsOfServiceURL,omitempty"`
+
 	// Enables requesting a Not After date on certificates that matches the
 	// duration of the certificate. This is not supported by all ACME servers
 	// like Let's Encrypt. If set to true when the ACME server does not support
@@ -629,4 +659,54 @@ type ACMEIssuerStatus struct {
 	// associated with the  Issuer
 	// +optional
 	LastRegisteredEmail string `json:"lastRegisteredEmail,omitempty"`
+
+	// AccountKeyParameters records the parameters that were used to generate
+	// the ACME account private key. It is only set when cert-manager generates
+	// the account key, and is left unchanged when an existing key is used.
+	// +optional
+	AccountKeyParameters *ACMEAccountKeyParameters `json:"accountKeyParameters,omitempty"`
+
+	// RegisteredAt is the time at which the ACME account was newly created
+	// by cert-manager. It is not set if cert-manager found an account that
+	// already existed on the ACME server for the account private key.
+	// +optional
+	RegisteredAt *metav1.Time `json:"registeredAt,omitempty"`
+
+	// TermsOfServiceURL is the URL of the ACME server's terms of service that
+	// were in effect when the ACME account was registered, or when it was
+	// first verified by cert-manager.
+	// +optional
+	TermsOfServiceURL string `json:"termsOfServiceURL,omitempty"`
+
+	// AccountKeyThumbprint is the base64url encoded SHA-256 JWK thumbprint, as
+	// defined in RFC 7638, of the public key of the ACME account. It is set
+	// when the account is registered or verified with the ACME server.
+	// +optional
+	AccountKeyThumbprint string `json:"accountKeyThumbprint,omitempty"`
+
+	// LastVerifiedAt is the time at which the ACME account was last
+	// registered or verified with the ACME server.
+	// +optional
+	LastVerifiedAt *metav1.Time `json:"lastVerifiedAt,omitempty"`
+}
+
+// ACMEAccountKeyParameters describes how an ACME account private key was
+// generated by cert-manager.
+type ACMEAccountKeyParameters struct {
+	// Algorithm is the private key algorithm of the account key, e.g. `RSA`.
+	// +optional
+	Algorithm string `json:"algorithm,omitempty"`
+
+	// Size is the size of the account key in bits.
+	// +optional
+	Size int `json:"size,omitempty"`
+
+	// Encoding is the encoding used to store the account key in the Secret,
+	// e.g. `PKCS1`.
+	// +optional
+	Encoding string `json:"encoding,omitempty"`
+
+	// PublicExponent is the public exponent of an RSA account key.
+	// +optional
+	PublicExponent int `json:"publicExponent,omitempty"`
 }
@@ -123,6 +123,12 @@ type OrderStatus struct {
 	// This is used to influence garbage collection and back-off.
 	// +optional
 	FailureTime *metav1.Time `json:"failureTime,omitempty"`
+
+	// RateLimitedUntil stores the time until which the ACME server asked for
+	// this order not to be retried after rejecting it due to a rate limit.
+	// The order will not be submitted to the ACME server again before then.
+	// +optional
+	RateLimitedUntil *metav1.Time `json:"rateLimitedUntil,omitempty"`
 }
 
 // ACMEAuthorization contains data returned from the ACME server on an
@@ -30,6 +30,22 @@ import (
 	apisv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEAccountKeyParameters) DeepCopyInto(out *ACMEAccountKeyParameters) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEAccountKeyParameters.
+func (in *ACMEAccountKeyParameters) DeepCopy() *ACMEAccountKeyParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEAccountKeyParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ACMEAuthorization) DeepCopyInto(out *ACMEAuthorization) {
 	*out = *in
@@ -631,6 +647,19 @@ func (in *ACMEIssuerDNS01ProviderWebhook) DeepCopy() *ACMEIssuerDNS01ProviderWeb
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ACMEIssuerStatus) DeepCopyInto(out *ACMEIssuerStatus) {
 	*out = *in
+	if in.AccountKeyParameters != nil {
+		in, out := &in.AccountKeyParameters, &out.AccountKeyParameters
+		*out = new(ACMEAccountKeyParameters)
+		**out = **in
+	}
+	if in.RegisteredAt != nil {
+		in, out := &in.RegisteredAt, &out.RegisteredAt
+		*out = (*in).DeepCopy()
+	}
+	if in.LastVerifiedAt != nil {
+		in, out := &in.LastVerifiedAt, &out.LastVerifiedAt
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -905,6 +934,10 @@ func (in *OrderStatus) DeepCopyInto(out *OrderStatus) {
 		in, out := &in.FailureTime, &out.FailureTime
 		*out = (*in).DeepCopy()
 	}
+	if in.RateLimitedUntil != nil {
+		in, out := &in.RateLimitedUntil, &out.RateLimitedUntil
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -747,7 +747,7 @@ func (in *IssuerStatus) DeepCopyInto(out *IssuerStatus) {
 	if in.ACME != nil {
 		in, out := &in.ACME, &out.ACME
 		*out = new(acmev1alpha3.ACMEIssuerStatus)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
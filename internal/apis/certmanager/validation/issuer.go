@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strings"
 
+	"golang.org/x/text/language"
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -124,10 +125,20 @@ func ValidateACMEIssuerConfig(iss *cmacme.ACMEIssuer, fldPath *field.Path) (fiel
 		el = append(el, field.Required(fldPath.Child("privateKeySecretRef", "name"), "private key secret name is a required field"))
 	}
 
+	if iss.RequireEmail && len(iss.Email) == 0 {
+		el = append(el, field.Required(fldPath.Child("email"), "email is a required field when requireEmail is true"))
+	}
+
 	if len(iss.Server) == 0 {
 		el = append(el, field.Required(fldPath.Child("server"), "acme server URL is a required field"))
 	}
 
+	if len(iss.PreferredLanguage) > 0 {
+		if _, err := language.Parse(iss.PreferredLanguage); err != nil {
+			el = append(el, field.Invalid(fldPath.Child("preferredLanguage"), iss.PreferredLanguage, fmt.Sprintf("must be a valid BCP 47 language tag: %v", err)))
+		}
+	}
+
 	if eab := iss.ExternalAccountBinding; eab != nil {
 		eabFldPath := fldPath.Child("externalAccountBinding")
 		if len(eab.KeyID) == 0 {
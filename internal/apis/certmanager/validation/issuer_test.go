@@ -444,6 +444,43 @@ func TestValidateACMEIssuerConfig(t *testing.T) {
 			},
 			warnings: []string{deprecatedACMEEABKeyAlgorithmField},
 		},
+		"acme issuer with requireEmail set and an email": {
+			spec: &cmacme.ACMEIssuer{
+				Email:        "valid-email",
+				Server:       "valid-server",
+				PrivateKey:   validSecretKeyRef,
+				RequireEmail: true,
+			},
+		},
+		"acme issuer with requireEmail set and no email": {
+			spec: &cmacme.ACMEIssuer{
+				Server:       "valid-server",
+				PrivateKey:   validSecretKeyRef,
+				RequireEmail: true,
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("email"), "email is a required field when requireEmail is true"),
+			},
+		},
+		"acme issuer with a valid preferred language": {
+			spec: &cmacme.ACMEIssuer{
+				Email:             "valid-email",
+				Server:            "valid-server",
+				PrivateKey:        validSecretKeyRef,
+				PreferredLanguage: "pt-BR",
+			},
+		},
+		"acme issuer with a malformed preferred language": {
+			spec: &cmacme.ACMEIssuer{
+				Email:             "valid-email",
+				Server:            "valid-server",
+				PrivateKey:        validSecretKeyRef,
+				PreferredLanguage: "english!",
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("preferredLanguage"), "english!", "must be a valid BCP 47 language tag: language: tag is not well-formed"),
+			},
+		},
 		"acme solver with missing http01 config type": {
 			spec: &cmacme.ACMEIssuer{
 				Email:      "valid-email",
@@ -747,7 +747,7 @@ func (in *IssuerStatus) DeepCopyInto(out *IssuerStatus) {
 	if in.ACME != nil {
 		in, out := &in.ACME, &out.ACME
 		*out = new(acme.ACMEIssuerStatus)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
@@ -81,6 +81,14 @@ const (
 	// they know cert-manager will need access to to speed up issuance.
 	// See https://github.com/cert-manager/cert-manager/blob/master/design/20221205-memory-management.md
 	SecretsFilteredCaching featuregate.Feature = "SecretsFilteredCaching"
+
+	// Alpha: v1.12
+	// ValidateACMEExternalAccountBinding enables a pre-flight check of an
+	// ACME Issuer's External Account Binding configuration against the ACME
+	// server's directory before an account is registered, and reports
+	// External Account Binding failures with a dedicated ErrACMEEABRejected
+	// condition reason instead of the general registration failure reason.
+	ValidateACMEExternalAccountBinding featuregate.Feature = "ValidateACMEExternalAccountBinding"
 )
 
 func init() {
@@ -100,4 +108,5 @@ var defaultCertManagerFeatureGates = map[featuregate.Feature]featuregate.Feature
 	StableCertificateRequestName:                     {Default: false, PreRelease: featuregate.Alpha},
 	UseCertificateRequestBasicConstraints:            {Default: false, PreRelease: featuregate.Alpha},
 	SecretsFilteredCaching:                           {Default: false, PreRelease: featuregate.Alpha},
+	ValidateACMEExternalAccountBinding:               {Default: false, PreRelease: featuregate.Alpha},
 }
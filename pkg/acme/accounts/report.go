@@ -0,0 +1,132 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accounts
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// RegistrationReport is a structured summary of the ACME account registration
+// of an Issuer or ClusterIssuer, intended to be exported as evidence for
+// compliance purposes. It is derived purely from the issuer's spec and status,
+// and never contains the raw ACME account URI.
+type RegistrationReport struct {
+	// Kind is the kind of the issuer, either Issuer or ClusterIssuer.
+	Kind string `json:"kind"`
+	// Namespace is the namespace of the issuer. It is empty for a
+	// ClusterIssuer.
+	Namespace string `json:"namespace,omitempty"`
+	// Name is the name of the issuer.
+	Name string `json:"name"`
+
+	// DirectoryHost is the host of the ACME server's directory URL.
+	DirectoryHost string `json:"directoryHost"`
+	// AccountURIHash is the hex encoded SHA-256 hash of the ACME account URI.
+	// It is empty if the account has not been registered.
+	AccountURIHash string `json:"accountURIHash,omitempty"`
+	// Contacts are the contacts last registered with the ACME account.
+	Contacts []string `json:"contacts,omitempty"`
+
+	// KeyAlgorithm is the algorithm of the account private key. It is only
+	// known if the key was generated by cert-manager.
+	KeyAlgorithm string `json:"keyAlgorithm,omitempty"`
+	// KeySize is the size in bits of the account private key. It is only
+	// known if the key was generated by cert-manager.
+	KeySize int `json:"keySize,omitempty"`
+	// KeyThumbprint is the base64url encoded SHA-256 JWK thumbprint of the
+	// public key of the account. It is empty if the account has not been
+	// verified with the ACME server since the thumbprint was first recorded.
+	KeyThumbprint string `json:"keyThumbprint,omitempty"`
+	// RegisteredAt is the time at which the ACME account was newly created by
+	// cert-manager. It is not known for pre-existing accounts.
+	RegisteredAt *metav1.Time `json:"registeredAt,omitempty"`
+	// LastVerifiedAt is the time at which the ACME account was last
+	// registered or verified with the ACME server.
+	LastVerifiedAt *metav1.Time `json:"lastVerifiedAt,omitempty"`
+
+	// Ready is the status of the issuer's Ready condition.
+	Ready cmmeta.ConditionStatus `json:"ready"`
+	// ReadyReason is the reason of the issuer's Ready condition.
+	ReadyReason string `json:"readyReason,omitempty"`
+	// LastTransitionTime is the time at which the status of the issuer's
+	// Ready condition last changed.
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// NewRegistrationReport builds a RegistrationReport for the given ACME issuer
+// from its spec and status. An error is returned if the issuer is not an ACME
+// issuer.
+func NewRegistrationReport(issuer cmapi.GenericIssuer) (*RegistrationReport, error) {
+	spec := issuer.GetSpec().ACME
+	if spec == nil {
+		return nil, fmt.Errorf("%s %q is not an ACME issuer", kindOf(issuer), issuer.GetObjectMeta().Name)
+	}
+
+	report := &RegistrationReport{
+		Kind:      kindOf(issuer),
+		Namespace: issuer.GetObjectMeta().Namespace,
+		Name:      issuer.GetObjectMeta().Name,
+		Ready:     cmmeta.ConditionUnknown,
+	}
+
+	if serverURL, err := url.Parse(spec.Server); err == nil {
+		report.DirectoryHost = serverURL.Host
+	}
+
+	if status := issuer.GetStatus().ACME; status != nil {
+		if status.URI != "" {
+			sum := sha256.Sum256([]byte(status.URI))
+			report.AccountURIHash = hex.EncodeToString(sum[:])
+		}
+		if status.LastRegisteredEmail != "" {
+			report.Contacts = []string{"mailto:" + status.LastRegisteredEmail}
+		}
+		report.RegisteredAt = status.RegisteredAt
+		report.LastVerifiedAt = status.LastVerifiedAt
+		report.KeyThumbprint = status.AccountKeyThumbprint
+		if params := status.AccountKeyParameters; params != nil {
+			report.KeyAlgorithm = params.Algorithm
+			report.KeySize = params.Size
+		}
+	}
+
+	for _, cond := range issuer.GetStatus().Conditions {
+		if cond.Type != cmapi.IssuerConditionReady {
+			continue
+		}
+		report.Ready = cond.Status
+		report.ReadyReason = cond.Reason
+		report.LastTransitionTime = cond.LastTransitionTime
+	}
+
+	return report, nil
+}
+
+func kindOf(issuer cmapi.GenericIssuer) string {
+	if _, ok := issuer.(*cmapi.ClusterIssuer); ok {
+		return cmapi.ClusterIssuerKind
+	}
+	return cmapi.IssuerKind
+}
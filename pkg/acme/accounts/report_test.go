@@ -0,0 +1,98 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accounts
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestNewRegistrationReport(t *testing.T) {
+	const accountURI = "https://acme.example.com/acme/acct/12345"
+
+	issuer := gen.Issuer("test",
+		gen.SetIssuerNamespace("default"),
+		gen.SetIssuerACME(cmacme.ACMEIssuer{Server: "https://acme.example.com/directory"}),
+		gen.SetIssuerACMEAccountURL(accountURI),
+		gen.SetIssuerACMELastRegisteredEmail("test@example.com"),
+		gen.AddIssuerCondition(cmapi.IssuerCondition{
+			Type:   cmapi.IssuerConditionReady,
+			Status: cmmeta.ConditionTrue,
+			Reason: "ACMEAccountRegistered",
+		}),
+	)
+	issuer.Status.ACME.AccountKeyParameters = &cmacme.ACMEAccountKeyParameters{Algorithm: "RSA", Size: 2048}
+	registeredAt := metav1.NewTime(time.Date(2023, time.March, 1, 12, 0, 0, 0, time.UTC))
+	issuer.Status.ACME.RegisteredAt = &registeredAt
+	lastVerifiedAt := metav1.NewTime(time.Date(2023, time.April, 1, 12, 0, 0, 0, time.UTC))
+	issuer.Status.ACME.LastVerifiedAt = &lastVerifiedAt
+	issuer.Status.ACME.AccountKeyThumbprint = "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs"
+
+	report, err := NewRegistrationReport(issuer)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := RegistrationReport{
+		Kind:           cmapi.IssuerKind,
+		Namespace:      "default",
+		Name:           "test",
+		DirectoryHost:  "acme.example.com",
+		AccountURIHash: "9790f6b5e980c2e2b804ad6a9b3761958354c1196c132a7983ebddae6a549418",
+		Contacts:       []string{"mailto:test@example.com"},
+		KeyAlgorithm:   "RSA",
+		KeySize:        2048,
+		KeyThumbprint:  "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs",
+		RegisteredAt:   &registeredAt,
+		LastVerifiedAt: &lastVerifiedAt,
+		Ready:          cmmeta.ConditionTrue,
+		ReadyReason:    "ACMEAccountRegistered",
+	}
+	report.LastTransitionTime = nil
+	if got, want := mustMarshal(t, report), mustMarshal(t, expected); got != want {
+		t.Errorf("unexpected report:\ngot:  %s\nwant: %s", got, want)
+	}
+
+	if out := mustMarshal(t, report); strings.Contains(out, accountURI) {
+		t.Errorf("report must not contain the raw account URI: %s", out)
+	}
+}
+
+func TestNewRegistrationReport_NotACME(t *testing.T) {
+	issuer := gen.ClusterIssuer("test", gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{}))
+	if _, err := NewRegistrationReport(issuer); err == nil {
+		t.Errorf("expected an error for a non-ACME issuer")
+	}
+}
+
+func mustMarshal(t *testing.T, v interface{}) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
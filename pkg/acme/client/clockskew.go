@@ -0,0 +1,89 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+// ClockSkewTransport is a http.RoundTripper that measures the difference
+// between the time reported by the ACME server in the Date header of the first
+// response it receives, and the local time at which that response was
+// received.
+type ClockSkewTransport struct {
+	wrappedRT http.RoundTripper
+	clock     clock.Clock
+
+	lock     sync.Mutex
+	skew     time.Duration
+	measured bool
+}
+
+// NewClockSkewTransport returns a ClockSkewTransport wrapping the given
+// RoundTripper. If rt is nil, http.DefaultTransport is used.
+func NewClockSkewTransport(rt http.RoundTripper, clock clock.Clock) *ClockSkewTransport {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+
+	return &ClockSkewTransport{
+		wrappedRT: rt,
+		clock:     clock,
+	}
+}
+
+// RoundTrip implements http.RoundTripper. It forwards the request to the
+// wrapped RoundTripper and measures the clock skew using the Date header of
+// the response, if it has not been measured yet.
+func (ct *ClockSkewTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := ct.wrappedRT.RoundTrip(req)
+	if resp == nil {
+		return resp, err
+	}
+	now := ct.clock.Now()
+
+	// A missing or malformed Date header should never cause a request to
+	// the ACME server to fail, so it is ignored.
+	serverTime, parseErr := http.ParseTime(resp.Header.Get("Date"))
+	if parseErr != nil {
+		return resp, err
+	}
+
+	ct.lock.Lock()
+	defer ct.lock.Unlock()
+	if !ct.measured {
+		ct.skew = serverTime.Sub(now)
+		ct.measured = true
+	}
+
+	return resp, err
+}
+
+// Skew returns the measured difference between the ACME server's clock and
+// the local clock. A positive value means the local clock is behind the ACME
+// server's clock. The boolean is false if no response with a valid Date
+// header has been received yet.
+func (ct *ClockSkewTransport) Skew() (time.Duration, bool) {
+	ct.lock.Lock()
+	defer ct.lock.Unlock()
+
+	return ct.skew, ct.measured
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	fakeclock "k8s.io/utils/clock/testing"
+)
+
+func TestClockSkewTransport(t *testing.T) {
+	serverTime := time.Date(2023, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		dateHeaders []string
+		localTime   time.Time
+		expSkew     time.Duration
+		expMeasured bool
+	}{
+		"no Date header is returned": {
+			dateHeaders: []string{"", ""},
+			localTime:   serverTime,
+			expMeasured: false,
+		},
+		"malformed Date header is ignored": {
+			dateHeaders: []string{"not a date"},
+			localTime:   serverTime,
+			expMeasured: false,
+		},
+		"local clock is behind the server clock": {
+			dateHeaders: []string{serverTime.Format(http.TimeFormat)},
+			localTime:   serverTime.Add(-5 * time.Minute),
+			expSkew:     5 * time.Minute,
+			expMeasured: true,
+		},
+		"local clock is ahead of the server clock": {
+			dateHeaders: []string{serverTime.Format(http.TimeFormat)},
+			localTime:   serverTime.Add(time.Hour),
+			expSkew:     -time.Hour,
+			expMeasured: true,
+		},
+		"only the first valid Date header is used": {
+			dateHeaders: []string{"", serverTime.Format(http.TimeFormat), serverTime.Add(time.Hour).Format(http.TimeFormat)},
+			localTime:   serverTime,
+			expSkew:     0,
+			expMeasured: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			i := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// Setting the header to nil prevents net/http from adding
+				// its own Date header.
+				w.Header()["Date"] = nil
+				if test.dateHeaders[i] != "" {
+					w.Header().Set("Date", test.dateHeaders[i])
+				}
+				i++
+			}))
+			defer server.Close()
+
+			ct := NewClockSkewTransport(nil, fakeclock.NewFakeClock(test.localTime))
+			cl := &http.Client{Transport: ct}
+			for range test.dateHeaders {
+				resp, err := cl.Get(server.URL)
+				if err != nil {
+					t.Fatal(err)
+				}
+				resp.Body.Close()
+			}
+
+			skew, measured := ct.Skew()
+			if measured != test.expMeasured {
+				t.Errorf("unexpected measured, exp=%t got=%t", test.expMeasured, measured)
+			}
+			if skew != test.expSkew {
+				t.Errorf("unexpected skew, exp=%s got=%s", test.expSkew, skew)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"net/http"
+)
+
+// CorrelationIDTransport is a http.RoundTripper that sets a header
+// containing a correlation ID on every request it processes, so that
+// requests can be correlated with the logs of the ACME server.
+type CorrelationIDTransport struct {
+	wrappedRT http.RoundTripper
+
+	header string
+	id     string
+}
+
+// NewCorrelationIDTransport returns a CorrelationIDTransport wrapping the
+// given RoundTripper, that sends id in the given header. If rt is nil,
+// http.DefaultTransport is used.
+func NewCorrelationIDTransport(rt http.RoundTripper, header, id string) *CorrelationIDTransport {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+
+	return &CorrelationIDTransport{
+		wrappedRT: rt,
+		header:    header,
+		id:        id,
+	}
+}
+
+// RoundTrip implements http.RoundTripper. It sets the correlation ID header
+// on a copy of the request and forwards it to the wrapped RoundTripper.
+func (ct *CorrelationIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A RoundTripper must not modify the request it is given.
+	req = req.Clone(req.Context())
+	req.Header.Set(ct.header, ct.id)
+
+	return ct.wrappedRT.RoundTrip(req)
+}
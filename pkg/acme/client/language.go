@@ -0,0 +1,56 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"net/http"
+)
+
+// AcceptLanguageTransport is a http.RoundTripper that sets the
+// Accept-Language header on every request it processes, so that ACME servers
+// return localized problem documents.
+type AcceptLanguageTransport struct {
+	wrappedRT http.RoundTripper
+
+	language string
+}
+
+// NewAcceptLanguageTransport returns an AcceptLanguageTransport wrapping the
+// given RoundTripper. If rt is nil, http.DefaultTransport is used.
+func NewAcceptLanguageTransport(rt http.RoundTripper, language string) *AcceptLanguageTransport {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+
+	return &AcceptLanguageTransport{
+		wrappedRT: rt,
+		language:  language,
+	}
+}
+
+// RoundTrip implements http.RoundTripper. It sets the Accept-Language header
+// on a copy of the request, unless the header is already set, and forwards it
+// to the wrapped RoundTripper.
+func (lt *AcceptLanguageTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if len(req.Header.Get("Accept-Language")) == 0 {
+		// A RoundTripper must not modify the request it is given.
+		req = req.Clone(req.Context())
+		req.Header.Set("Accept-Language", lt.language)
+	}
+
+	return lt.wrappedRT.RoundTrip(req)
+}
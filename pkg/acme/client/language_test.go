@@ -0,0 +1,71 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAcceptLanguageTransport(t *testing.T) {
+	tests := map[string]struct {
+		requestLanguage string
+		expLanguage     string
+	}{
+		"header is set if the request does not have one": {
+			requestLanguage: "",
+			expLanguage:     "de",
+		},
+		"header already set on the request is preserved": {
+			requestLanguage: "fr",
+			expLanguage:     "fr",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var gotLanguage string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotLanguage = r.Header.Get("Accept-Language")
+			}))
+			defer server.Close()
+
+			req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(test.requestLanguage) > 0 {
+				req.Header.Set("Accept-Language", test.requestLanguage)
+			}
+
+			cl := &http.Client{Transport: NewAcceptLanguageTransport(nil, "de")}
+			resp, err := cl.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+
+			if gotLanguage != test.expLanguage {
+				t.Errorf("unexpected Accept-Language header, exp=%q got=%q", test.expLanguage, gotLanguage)
+			}
+			if req.Header.Get("Accept-Language") != test.requestLanguage {
+				t.Errorf("original request was modified")
+			}
+		})
+	}
+}
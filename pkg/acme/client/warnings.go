@@ -0,0 +1,89 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"net/http"
+	"sync"
+
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+)
+
+// persistentWarningCode is the RFC 7234 warn-code for a miscellaneous
+// persistent warning, which servers use to signal deprecated behaviour.
+const persistentWarningCode = 299
+
+// WarningTransport is a http.RoundTripper that records the persistent
+// warnings that the ACME server returns in RFC 7234 Warning headers. Warnings
+// are deduplicated by their text.
+type WarningTransport struct {
+	wrappedRT http.RoundTripper
+
+	lock     sync.Mutex
+	warnings []string
+	seen     map[string]struct{}
+}
+
+// NewWarningTransport returns a WarningTransport wrapping the given
+// RoundTripper. If rt is nil, http.DefaultTransport is used.
+func NewWarningTransport(rt http.RoundTripper) *WarningTransport {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+
+	return &WarningTransport{
+		wrappedRT: rt,
+		seen:      make(map[string]struct{}),
+	}
+}
+
+// RoundTrip implements http.RoundTripper. It forwards the request to the
+// wrapped RoundTripper and records any persistent warnings in the response.
+func (wt *WarningTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := wt.wrappedRT.RoundTrip(req)
+	if resp == nil {
+		return resp, err
+	}
+
+	// Malformed Warning headers are ignored, as they should never cause a
+	// request to the ACME server to fail.
+	headers, _ := utilnet.ParseWarningHeaders(resp.Header.Values("Warning"))
+
+	wt.lock.Lock()
+	defer wt.lock.Unlock()
+	for _, h := range headers {
+		if h.Code != persistentWarningCode {
+			continue
+		}
+		if _, ok := wt.seen[h.Text]; ok {
+			continue
+		}
+		wt.seen[h.Text] = struct{}{}
+		wt.warnings = append(wt.warnings, h.Text)
+	}
+
+	return resp, err
+}
+
+// Warnings returns the text of all persistent warnings recorded so far, in the
+// order they were first seen.
+func (wt *WarningTransport) Warnings() []string {
+	wt.lock.Lock()
+	defer wt.lock.Unlock()
+
+	return append([]string(nil), wt.warnings...)
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestWarningTransport(t *testing.T) {
+	tests := map[string]struct {
+		responseWarnings [][]string
+		expWarnings      []string
+	}{
+		"no warnings returned": {
+			responseWarnings: [][]string{nil, nil},
+			expWarnings:      nil,
+		},
+		"persistent warnings are recorded in order": {
+			responseWarnings: [][]string{
+				{`299 - "the v1 directory is deprecated"`},
+				{`299 - "the foo field is deprecated"`},
+			},
+			expWarnings: []string{"the v1 directory is deprecated", "the foo field is deprecated"},
+		},
+		"duplicate warnings are only recorded once": {
+			responseWarnings: [][]string{
+				{`299 - "the v1 directory is deprecated"`},
+				{`299 - "the v1 directory is deprecated"`, `299 - "the v1 directory is deprecated"`},
+			},
+			expWarnings: []string{"the v1 directory is deprecated"},
+		},
+		"non-persistent and malformed warnings are ignored": {
+			responseWarnings: [][]string{
+				{`199 - "response is stale"`},
+				{`not a warning`},
+			},
+			expWarnings: nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			i := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for _, warning := range test.responseWarnings[i] {
+					w.Header().Add("Warning", warning)
+				}
+				i++
+			}))
+			defer server.Close()
+
+			wt := NewWarningTransport(nil)
+			cl := &http.Client{Transport: wt}
+			for range test.responseWarnings {
+				resp, err := cl.Get(server.URL)
+				if err != nil {
+					t.Fatal(err)
+				}
+				resp.Body.Close()
+			}
+
+			if got := wt.Warnings(); !reflect.DeepEqual(got, test.expWarnings) {
+				t.Errorf("unexpected warnings, exp=%q got=%q", test.expWarnings, got)
+			}
+		})
+	}
+}
@@ -18,14 +18,22 @@ package util
 
 import (
 	"crypto/rand"
+	"errors"
 	"math/big"
 	"net/http"
+	"strconv"
 	"time"
+
+	acmeapi "golang.org/x/crypto/acme"
 )
 
 const (
 	maxDelay   = 3 * time.Second
 	maxRetries = 5
+
+	// rateLimitedProblemType is returned by ACME servers when a rate limit
+	// has been exceeded. Defined in RFC 8555 Section 6.7.
+	rateLimitedProblemType = "urn:ietf:params:acme:error:rateLimited"
 )
 
 // RetryBackoff is the ACME client RetryBackoff which is modified
@@ -59,3 +67,30 @@ func RetryBackoff(n int, r *http.Request, resp *http.Response) time.Duration {
 	}
 	return d
 }
+
+// IsRateLimitedError returns true if err is an ACME error returned because a
+// rate limit of the ACME server was exceeded.
+func IsRateLimitedError(err error) bool {
+	var acmeErr *acmeapi.Error
+	return errors.As(err, &acmeErr) && acmeErr.ProblemType == rateLimitedProblemType
+}
+
+// RateLimitedUntil returns the time until which the ACME server asked for
+// requests to not be retried, if err is an ACME rateLimited error whose
+// response set a valid Retry-After header. The header may either be a number
+// of seconds relative to now, or an HTTP date.
+func RateLimitedUntil(err error, now time.Time) (time.Time, bool) {
+	var acmeErr *acmeapi.Error
+	if !errors.As(err, &acmeErr) || acmeErr.ProblemType != rateLimitedProblemType {
+		return time.Time{}, false
+	}
+
+	retryAfter := acmeErr.Header.Get("Retry-After")
+	if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+		return now.Add(time.Duration(seconds) * time.Second), true
+	}
+	if t, err := http.ParseTime(retryAfter); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
@@ -17,9 +17,12 @@ limitations under the License.
 package util
 
 import (
+	"errors"
 	"net/http"
 	"testing"
 	"time"
+
+	acmeapi "golang.org/x/crypto/acme"
 )
 
 func TestRetryBackoff(t *testing.T) {
@@ -86,3 +89,65 @@ func TestRetryBackoff(t *testing.T) {
 		})
 	}
 }
+
+func TestRateLimitedUntil(t *testing.T) {
+	now := time.Date(2023, time.March, 1, 12, 0, 0, 0, time.UTC)
+	rateLimited := func(retryAfter string) error {
+		return &acmeapi.Error{
+			StatusCode:  http.StatusTooManyRequests,
+			ProblemType: "urn:ietf:params:acme:error:rateLimited",
+			Header:      http.Header{"Retry-After": []string{retryAfter}},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		err       error
+		wantTime  time.Time
+		wantLimit bool
+	}{
+		{
+			name:      "Retry-After in seconds",
+			err:       rateLimited("3600"),
+			wantTime:  now.Add(time.Hour),
+			wantLimit: true,
+		},
+		{
+			name:      "Retry-After as an HTTP date",
+			err:       rateLimited("Wed, 01 Mar 2023 14:00:00 GMT"),
+			wantTime:  now.Add(2 * time.Hour),
+			wantLimit: true,
+		},
+		{
+			name: "Invalid Retry-After",
+			err:  rateLimited("soon"),
+		},
+		{
+			name: "Missing Retry-After",
+			err: &acmeapi.Error{
+				StatusCode:  http.StatusTooManyRequests,
+				ProblemType: "urn:ietf:params:acme:error:rateLimited",
+			},
+		},
+		{
+			name: "Not a rateLimited error",
+			err: &acmeapi.Error{
+				StatusCode:  http.StatusForbidden,
+				ProblemType: "urn:ietf:params:acme:error:unauthorized",
+				Header:      http.Header{"Retry-After": []string{"3600"}},
+			},
+		},
+		{
+			name: "Not an ACME error",
+			err:  errors.New("connection refused"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotTime, gotLimit := RateLimitedUntil(tt.err, now)
+			if gotLimit != tt.wantLimit || !gotTime.Equal(tt.wantTime) {
+				t.Errorf("RateLimitedUntil() = (%v, %v), want (%v, %v)", gotTime, gotLimit, tt.wantTime, tt.wantLimit)
+			}
+		})
+	}
+}
@@ -36,12 +36,21 @@ type Scheduler struct {
 	log                     logr.Logger
 	challengeLister         cmacmelisters.ChallengeLister
 	maxConcurrentChallenges int
+	// maxConcurrentChallengesPerDNSProvider limits the number of DNS01
+	// challenges that may be processing at once for each DNS provider.
+	// Zero means that no per-provider limit is applied.
+	maxConcurrentChallengesPerDNSProvider int
 }
 
 // New will construct a new instance of a scheduler
-func New(ctx context.Context, l cmacmelisters.ChallengeLister, maxConcurrentChallenges int) *Scheduler {
+func New(ctx context.Context, l cmacmelisters.ChallengeLister, maxConcurrentChallenges, maxConcurrentChallengesPerDNSProvider int) *Scheduler {
 	log := logs.FromContext(ctx, "challenge-scheduler")
-	return &Scheduler{log: log, challengeLister: l, maxConcurrentChallenges: maxConcurrentChallenges}
+	return &Scheduler{
+		log:                                   log,
+		challengeLister:                       l,
+		maxConcurrentChallenges:               maxConcurrentChallenges,
+		maxConcurrentChallengesPerDNSProvider: maxConcurrentChallengesPerDNSProvider,
+	}
 }
 
 // ScheduleN will return a maximum of N challenge resources that should be
@@ -76,6 +85,8 @@ func (s *Scheduler) scheduleN(n int, allChallenges []*cmacme.Challenge) ([]*cmac
 		numberToSelect = remainingNumberAllowedChallenges
 	}
 
+	candidates = s.limitChallengesPerDNSProvider(candidates, processingChallenges(allChallenges))
+
 	candidates, err = s.selectChallengesToSchedule(candidates, numberToSelect)
 	if err != nil {
 		return nil, err
@@ -95,6 +106,69 @@ func (s *Scheduler) selectChallengesToSchedule(candidates []*cmacme.Challenge, n
 	return candidates, nil
 }
 
+// limitChallengesPerDNSProvider filters out candidates that would exceed the
+// maximum number of challenges processing at once for their DNS provider,
+// taking into account the challenges that are already processing. The order
+// of the candidates is preserved, so older challenges are preferred.
+func (s *Scheduler) limitChallengesPerDNSProvider(candidates, inProgress []*cmacme.Challenge) []*cmacme.Challenge {
+	if s.maxConcurrentChallengesPerDNSProvider <= 0 {
+		return candidates
+	}
+
+	counts := make(map[string]int)
+	for _, ch := range inProgress {
+		if provider := dnsProviderName(ch); provider != "" {
+			counts[provider]++
+		}
+	}
+
+	return filterChallenges(candidates, func(ch *cmacme.Challenge) bool {
+		provider := dnsProviderName(ch)
+		if provider == "" {
+			return true
+		}
+		if counts[provider] >= s.maxConcurrentChallengesPerDNSProvider {
+			s.log.V(logs.DebugLevel).Info("hit maximum concurrent challenge limit for DNS provider", "provider", provider, "max_concurrent", s.maxConcurrentChallengesPerDNSProvider)
+			return false
+		}
+		counts[provider]++
+		return true
+	})
+}
+
+// dnsProviderName returns a name identifying the DNS provider used to solve
+// the given challenge, or an empty string if it is not a DNS01 challenge.
+// Webhook solvers are identified by their group and solver name.
+func dnsProviderName(ch *cmacme.Challenge) string {
+	dns01 := ch.Spec.Solver.DNS01
+	if dns01 == nil {
+		return ""
+	}
+
+	switch {
+	case dns01.Akamai != nil:
+		return "akamai"
+	case dns01.CloudDNS != nil:
+		return "clouddns"
+	case dns01.Cloudflare != nil:
+		return "cloudflare"
+	case dns01.Route53 != nil:
+		return "route53"
+	case dns01.AzureDNS != nil:
+		return "azuredns"
+	case dns01.DigitalOcean != nil:
+		return "digitalocean"
+	case dns01.AcmeDNS != nil:
+		return "acmedns"
+	case dns01.RFC2136 != nil:
+		return "rfc2136"
+	case dns01.Webhook != nil:
+		return "webhook/" + dns01.Webhook.GroupName + "/" + dns01.Webhook.SolverName
+	default:
+		return ""
+	}
+}
+
 // determineChallengeCandidates will determine which, if any, challenges can
 // be scheduled given the current state of items to be scheduled and currently
 // processing.
@@ -23,6 +23,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/diff"
@@ -319,7 +320,7 @@ func TestScheduleN(t *testing.T) {
 				require.NoError(t, err)
 			}
 
-			s := New(context.Background(), challengesInformer.Lister(), maxConcurrentChallenges)
+			s := New(context.Background(), challengesInformer.Lister(), maxConcurrentChallenges, 0)
 
 			if test.expected == nil {
 				test.expected = []*cmacme.Challenge{}
@@ -337,3 +338,77 @@ func TestScheduleN(t *testing.T) {
 		})
 	}
 }
+
+func withDNS01Solver(dns01 *cmacme.ACMEChallengeSolverDNS01) func(*cmacme.Challenge) {
+	return func(ch *cmacme.Challenge) {
+		ch.Spec.Type = cmacme.ACMEChallengeTypeDNS01
+		ch.Spec.Solver.DNS01 = dns01
+	}
+}
+
+func TestScheduleNPerDNSProvider(t *testing.T) {
+	route53 := withDNS01Solver(&cmacme.ACMEChallengeSolverDNS01{Route53: &cmacme.ACMEIssuerDNS01ProviderRoute53{}})
+	cloudflare := withDNS01Solver(&cmacme.ACMEChallengeSolverDNS01{Cloudflare: &cmacme.ACMEIssuerDNS01ProviderCloudflare{}})
+	webhookA := withDNS01Solver(&cmacme.ACMEChallengeSolverDNS01{Webhook: &cmacme.ACMEIssuerDNS01ProviderWebhook{GroupName: "example.com", SolverName: "a"}})
+	webhookB := withDNS01Solver(&cmacme.ACMEChallengeSolverDNS01{Webhook: &cmacme.ACMEIssuerDNS01ProviderWebhook{GroupName: "example.com", SolverName: "b"}})
+
+	route53Chs := ascendingChallengeN(3, route53)
+	cloudflareChs := ascendingChallengeN(2, cloudflare, withCreationTimestamp(10))
+	http01Chs := ascendingChallengeN(2, withCreationTimestamp(20))
+	for i, ch := range cloudflareChs {
+		ch.Spec.DNSName = fmt.Sprintf("cloudflare-%d", i)
+	}
+	for i, ch := range http01Chs {
+		ch.Spec.DNSName = fmt.Sprintf("http01-%d", i)
+	}
+
+	webhookAChs := ascendingChallengeN(2, webhookA)
+	webhookBCh := ascendingChallengeN(1, webhookB, withCreationTimestamp(10))[0]
+	webhookBCh.Spec.DNSName = "webhook-b"
+
+	processingRoute53Ch := route53Chs[0].DeepCopy()
+	processingRoute53Ch.Name = "processing"
+	processingRoute53Ch.Spec.DNSName = "processing"
+	processingRoute53Ch.Status.Processing = true
+
+	tests := map[string]struct {
+		maxPerDNSProvider int
+		challenges        []*cmacme.Challenge
+		expected          []*cmacme.Challenge
+	}{
+		"no per-provider limit schedules all challenges": {
+			challenges: append(append([]*cmacme.Challenge{}, route53Chs...), cloudflareChs...),
+			expected:   append(append([]*cmacme.Challenge{}, route53Chs...), cloudflareChs...),
+		},
+		"oldest challenges for each provider are scheduled up to the limit": {
+			maxPerDNSProvider: 1,
+			challenges:        append(append(append([]*cmacme.Challenge{}, route53Chs...), cloudflareChs...), http01Chs...),
+			expected:          []*cmacme.Challenge{route53Chs[0], cloudflareChs[0], http01Chs[0], http01Chs[1]},
+		},
+		"challenges already processing count towards the limit": {
+			maxPerDNSProvider: 2,
+			challenges:        append([]*cmacme.Challenge{processingRoute53Ch}, route53Chs...),
+			expected:          []*cmacme.Challenge{route53Chs[0]},
+		},
+		"webhook solvers are limited separately by solver name": {
+			maxPerDNSProvider: 1,
+			challenges:        append([]*cmacme.Challenge{webhookBCh}, webhookAChs...),
+			expected:          []*cmacme.Challenge{webhookAChs[0], webhookBCh},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := &Scheduler{
+				log:                                   logr.Discard(),
+				maxConcurrentChallenges:               maxConcurrentChallenges,
+				maxConcurrentChallengesPerDNSProvider: test.maxPerDNSProvider,
+			}
+			chs, err := s.scheduleN(10, test.challenges)
+			require.NoError(t, err)
+			if !reflect.DeepEqual(chs, test.expected) {
+				t.Errorf("expected did not match actual: %v", diff.ObjectDiff(test.expected, chs))
+			}
+		})
+	}
+}
@@ -35,6 +35,7 @@ import (
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/issuer"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 	"github.com/cert-manager/cert-manager/pkg/scheduler"
 )
 
@@ -70,6 +71,15 @@ type controller struct {
 
 	// scheduledWorkQueue holds items to be re-queued after a period of time.
 	scheduledWorkQueue scheduler.ScheduledWorkQueue
+
+	// orderTimeout is the time after which an Order that is still waiting
+	// for its Challenges to complete is marked as failed. If zero, Orders
+	// never time out.
+	orderTimeout time.Duration
+
+	// metrics is used to count the Orders that were rate limited by the
+	// ACME server.
+	metrics *metrics.Metrics
 }
 
 // NewController constructs an orders controller using the provided options.
@@ -147,6 +157,8 @@ func NewController(
 		cmClient:            ctx.CMClient,
 		accountRegistry:     ctx.AccountRegistry,
 		fieldManager:        ctx.FieldManager,
+		orderTimeout:        ctx.ACMEOptions.OrderTimeout,
+		metrics:             ctx.Metrics,
 	}, queue, mustSync
 
 }
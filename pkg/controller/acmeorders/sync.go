@@ -38,6 +38,7 @@ import (
 	internalorders "github.com/cert-manager/cert-manager/internal/controller/orders"
 	"github.com/cert-manager/cert-manager/pkg/acme"
 	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
+	acmeutil "github.com/cert-manager/cert-manager/pkg/acme/util"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
@@ -93,7 +94,7 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 		return nil
 	case o.Status.URL == "":
 		log.V(logf.DebugLevel).Info("Creating new ACME order as status.url is not set")
-		return c.createOrder(ctx, cl, o)
+		return c.createOrder(ctx, cl, o, genericIssuer)
 	case o.Status.FinalizeURL == "":
 		log.V(logf.DebugLevel).Info("Updating Order status as status.finalizeURL is not set")
 		_, err := c.updateOrderStatus(ctx, cl, o)
@@ -169,6 +170,25 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 	// we can return without taking any action. This controller will resync
 	// the Order on any owned Challenge events.
 	if !anyChallengesFailed(challenges) && !allChallengesFinal(challenges) {
+		if c.orderTimeout > 0 {
+			remaining := o.CreationTimestamp.Add(c.orderTimeout).Sub(c.clock.Now())
+			if remaining <= 0 {
+				log.V(logf.InfoLevel).Info("Order has not completed within the configured timeout, marking Order as failed and cleaning up owned Challenge resources", "timeout", c.orderTimeout)
+				c.setOrderState(&o.Status, string(cmacme.Errored))
+				o.Status.Reason = orderTimeoutReason(c.orderTimeout, challenges)
+				return c.deleteAllChallenges(ctx, o)
+			}
+
+			key, err := cache.MetaNamespaceKeyFunc(o)
+			if err != nil {
+				log.Error(err, "failed to construct key for pending Order")
+				return nil
+			}
+			// Re-queue the Order to be processed again once the timeout has
+			// elapsed, in case no owned Challenge changes before then.
+			c.scheduledWorkQueue.Add(key, remaining)
+		}
+
 		log.V(logf.DebugLevel).Info("No action taken")
 		return nil
 	}
@@ -259,12 +279,30 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 	return nil
 }
 
-func (c *controller) createOrder(ctx context.Context, cl acmecl.Interface, o *cmacme.Order) error {
+func (c *controller) createOrder(ctx context.Context, cl acmecl.Interface, o *cmacme.Order, issuer cmapi.GenericIssuer) error {
 	log := logf.FromContext(ctx)
 
 	if o.Status.URL != "" {
 		return fmt.Errorf("refusing to recreate a new order for Order %q. Please create a new Order resource to initiate a new order", o.Name)
 	}
+
+	// The ACME server previously rejected this order due to a rate limit and
+	// told us when to retry. Do not submit it again before then, as the
+	// status update made when the rate limit was hit re-queues the Order
+	// straight away.
+	if o.Status.RateLimitedUntil != nil {
+		if remaining := o.Status.RateLimitedUntil.Time.Sub(c.clock.Now()); remaining > 0 {
+			key, err := cache.MetaNamespaceKeyFunc(o)
+			if err != nil {
+				log.Error(err, "failed to construct key for rate limited Order")
+				return nil
+			}
+			log.V(logf.DebugLevel).Info("Order is rate limited by the ACME server, waiting before submitting it again", "rateLimitedUntil", o.Status.RateLimitedUntil.Time)
+			c.scheduledWorkQueue.Add(key, remaining)
+			return nil
+		}
+	}
+
 	log.V(logf.DebugLevel).Info("order URL not set, submitting Order to ACME server")
 
 	dnsIdentifierSet := sets.NewString(o.Spec.DNSNames...)
@@ -285,6 +323,26 @@ func (c *controller) createOrder(ctx context.Context, cl acmecl.Interface, o *cm
 		options = append(options, acmeapi.WithOrderNotAfter(c.clock.Now().Add(o.Spec.Duration.Duration)))
 	}
 	acmeOrder, err := cl.AuthorizeOrder(ctx, authzIDs, options...)
+	if acmeutil.IsRateLimitedError(err) {
+		c.metrics.IncrementACMERateLimitedCount(issuer.GetNamespace(), o.Spec.IssuerRef)
+
+		// If the ACME server told us when to retry, wait until then rather
+		// than failing the Order, which would only be retried after the
+		// Certificate's own backoff.
+		if retryAfter, ok := acmeutil.RateLimitedUntil(err, c.clock.Now()); ok {
+			key, keyErr := cache.MetaNamespaceKeyFunc(o)
+			if keyErr != nil {
+				log.Error(keyErr, "failed to construct key for rate limited Order")
+				return nil
+			}
+			log.Error(err, "failed to create Order resource due to a rate limit, retrying at the time advertised by the ACME server", "retryAfter", retryAfter)
+			rateLimitedUntil := metav1.NewTime(retryAfter)
+			o.Status.RateLimitedUntil = &rateLimitedUntil
+			o.Status.Reason = fmt.Sprintf("Rate limited by the ACME server, retrying after %s: %v", retryAfter.UTC().Format(time.RFC3339), err)
+			c.scheduledWorkQueue.Add(key, retryAfter.Sub(c.clock.Now()))
+			return nil
+		}
+	}
 	if acmeErr, ok := err.(*acmeapi.Error); ok {
 		if acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500 {
 			log.Error(err, "failed to create Order resource due to bad request, marking Order as failed")
@@ -300,6 +358,8 @@ func (c *controller) createOrder(ctx context.Context, cl acmecl.Interface, o *cm
 
 	o.Status.URL = acmeOrder.URI
 	o.Status.FinalizeURL = acmeOrder.FinalizeURL
+	o.Status.Reason = ""
+	o.Status.RateLimitedUntil = nil
 	o.Status.Authorizations = constructAuthorizations(acmeOrder)
 	c.setOrderState(&o.Status, acmeOrder.Status)
 
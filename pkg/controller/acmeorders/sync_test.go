@@ -21,6 +21,7 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
@@ -139,6 +140,17 @@ func TestSync(t *testing.T) {
 		StatusCode: 429,
 		Detail:     "some error",
 	}
+	acmeErrorRateLimited := acmeapi.Error{
+		StatusCode:  429,
+		ProblemType: "urn:ietf:params:acme:error:rateLimited",
+		Detail:      "too many certificates already issued",
+		Header:      http.Header{"Retry-After": []string{"3600"}},
+	}
+	rateLimitedUntil := metav1.NewTime(nowTime.Add(time.Hour))
+	testOrderRateLimited := gen.OrderFrom(testOrder, gen.SetOrderStatus(cmacme.OrderStatus{
+		Reason:           "Rate limited by the ACME server",
+		RateLimitedUntil: &rateLimitedUntil,
+	}))
 	acmeError403 := acmeapi.Error{
 		StatusCode: 403,
 		Detail:     "some error",
@@ -220,6 +232,15 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 	testAuthorizationChallengeValid.Status.State = cmacme.Valid
 	testAuthorizationChallengeInvalid := testAuthorizationChallenge.DeepCopy()
 	testAuthorizationChallengeInvalid.Status.State = cmacme.Invalid
+	testAuthorizationChallengeWithReason := testAuthorizationChallenge.DeepCopy()
+	testAuthorizationChallengeWithReason.Status.Reason = "Waiting for HTTP-01 challenge propagation"
+
+	testOrderPendingRecent := testOrderPending.DeepCopy()
+	testOrderPendingRecent.CreationTimestamp = nowMetaTime
+	testOrderTimedOut := testOrderPending.DeepCopy()
+	testOrderTimedOut.Status.State = cmacme.Errored
+	testOrderTimedOut.Status.FailureTime = &nowMetaTime
+	testOrderTimedOut.Status.Reason = "Order did not complete within 1h0m0s: test.com: Waiting for HTTP-01 challenge propagation"
 
 	testACMEAuthorizationPending := &acmeapi.Authorization{
 		URI:    "http://authzurl",
@@ -297,6 +318,40 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 				},
 			},
 		},
+		"wait until the time advertised by the acme server if creating the order is rate limited": {
+			order: testOrder,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrder},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrder.Namespace,
+						gen.OrderFrom(testOrder, gen.SetOrderStatus(cmacme.OrderStatus{
+							Reason: fmt.Sprintf("Rate limited by the ACME server, retrying after %s: %v",
+								nowTime.Add(time.Hour).UTC().Format(time.RFC3339), &acmeErrorRateLimited),
+							RateLimitedUntil: &rateLimitedUntil,
+						})))),
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeAuthorizeOrder: func(ctx context.Context, id []acmeapi.AuthzID, opt ...acmeapi.OrderOption) (*acmeapi.Order, error) {
+					return nil, &acmeErrorRateLimited
+				},
+			},
+			shouldSchedule: true,
+		},
+		"do not submit a rate limited order to the acme server again before the time advertised by the acme server": {
+			order: testOrderRateLimited,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderRateLimited},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeAuthorizeOrder: func(ctx context.Context, id []acmeapi.AuthzID, opt ...acmeapi.OrderOption) (*acmeapi.Order, error) {
+					return nil, errors.New("AuthorizeOrder should not be called for a rate limited Order")
+				},
+			},
+			shouldSchedule: true,
+		},
 		"create a new order with the acme server with an IP address": {
 			order: testOrderIP,
 			builder: &testpkg.Builder{
@@ -502,6 +557,39 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 				},
 			},
 		},
+		"requeue the order for when it times out if the challenge for test.com is still pending": {
+			order: testOrderPendingRecent,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderPendingRecent, testAuthorizationChallengeWithReason},
+				ExpectedActions:    []testpkg.Action{},
+			},
+			orderTimeout: time.Hour,
+			acmeClient: &acmecl.FakeACME{
+				FakeHTTP01ChallengeResponse: func(s string) (string, error) {
+					return "key", nil
+				},
+			},
+			shouldSchedule: true,
+		},
+		"mark the order as errored and delete its challenges if it has timed out": {
+			order: testOrderPending,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderPending, testAuthorizationChallengeWithReason},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewDeleteAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						testAuthorizationChallengeWithReason.Namespace, testAuthorizationChallengeWithReason.Name)),
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrderTimedOut.Namespace, testOrderTimedOut)),
+				},
+			},
+			orderTimeout: time.Hour,
+			acmeClient: &acmecl.FakeACME{
+				FakeHTTP01ChallengeResponse: func(s string) (string, error) {
+					return "key", nil
+				},
+			},
+		},
 		"call GetOrder and update the order state to 'ready' if all challenges are 'valid'": {
 			order: testOrderPending,
 			builder: &testpkg.Builder{
@@ -797,6 +885,7 @@ type testT struct {
 	order          *cmacme.Order
 	builder        *testpkg.Builder
 	acmeClient     acmecl.Interface
+	orderTimeout   time.Duration
 	shouldSchedule bool
 	expectErr      bool
 }
@@ -825,6 +914,7 @@ func runTest(t *testing.T, test testT) {
 		},
 	}
 	cw.scheduledWorkQueue = &fakeScheduler
+	cw.orderTimeout = test.orderTimeout
 
 	test.builder.Start()
 
@@ -841,3 +931,98 @@ func runTest(t *testing.T, test testT) {
 
 	test.builder.CheckAndFinish(err)
 }
+
+// TestSyncRateLimitedOrder checks that the status update made when creating
+// an order is rate limited does not cause the order to be submitted to the
+// ACME server again before the time advertised by the ACME server.
+func TestSyncRateLimitedOrder(t *testing.T) {
+	nowTime := time.Now()
+	fixedClock := fakeclock.NewFakeClock(nowTime)
+
+	testIssuer := gen.Issuer("testissuer", gen.SetIssuerACME(cmacme.ACMEIssuer{
+		Solvers: []cmacme.ACMEChallengeSolver{
+			{
+				HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+					Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{},
+				},
+			},
+		},
+	}))
+	testOrder := gen.Order("testorder",
+		gen.SetOrderCommonName("test.com"),
+		gen.SetOrderIssuer(cmmeta.ObjectReference{
+			Name: testIssuer.Name,
+		}),
+	)
+
+	builder := &testpkg.Builder{
+		T:                  t,
+		Clock:              fixedClock,
+		CertManagerObjects: []runtime.Object{testIssuer, testOrder},
+	}
+	builder.Init()
+	defer builder.Stop()
+
+	cw := &controllerWrapper{}
+	if _, _, err := cw.Register(builder.Context); err != nil {
+		t.Fatalf("Error registering the controller: %v", err)
+	}
+
+	authorizeOrderCalls := 0
+	cw.accountRegistry = &accountstest.FakeRegistry{
+		GetClientFunc: func(_ string) (acmecl.Interface, error) {
+			return &acmecl.FakeACME{
+				FakeAuthorizeOrder: func(ctx context.Context, id []acmeapi.AuthzID, opt ...acmeapi.OrderOption) (*acmeapi.Order, error) {
+					authorizeOrderCalls++
+					return nil, &acmeapi.Error{
+						StatusCode:  429,
+						ProblemType: "urn:ietf:params:acme:error:rateLimited",
+						Detail:      "too many certificates already issued",
+						Header:      http.Header{"Retry-After": []string{"3600"}},
+					}
+				},
+			}, nil
+		},
+	}
+	var scheduled []time.Duration
+	cw.scheduledWorkQueue = &schedulertest.FakeScheduler{
+		AddFunc: func(obj interface{}, duration time.Duration) {
+			scheduled = append(scheduled, duration)
+		},
+	}
+
+	builder.Start()
+
+	syncLatest := func() {
+		t.Helper()
+		o, err := builder.CMClient.AcmeV1().Orders(testOrder.Namespace).Get(context.Background(), testOrder.Name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Failed to get Order: %v", err)
+		}
+		if err := cw.Sync(context.Background(), o); err != nil {
+			t.Fatalf("Expected function to not error, but got: %v", err)
+		}
+	}
+
+	syncLatest()
+	if authorizeOrderCalls != 1 {
+		t.Fatalf("Expected AuthorizeOrder to be called once, got %d calls", authorizeOrderCalls)
+	}
+
+	// Sync the Order again as the status update above would, half way
+	// through the rate limit.
+	fixedClock.Step(30 * time.Minute)
+	syncLatest()
+	if authorizeOrderCalls != 1 {
+		t.Errorf("Expected AuthorizeOrder not to be called again before the rate limit lifts, got %d calls", authorizeOrderCalls)
+	}
+	if len(scheduled) != 2 || scheduled[1] != 30*time.Minute {
+		t.Errorf("Expected the Order to be re-queued for the remaining 30m of the rate limit, got %v", scheduled)
+	}
+
+	fixedClock.Step(30 * time.Minute)
+	syncLatest()
+	if authorizeOrderCalls != 2 {
+		t.Errorf("Expected AuthorizeOrder to be called again once the rate limit lifts, got %d calls", authorizeOrderCalls)
+	}
+}
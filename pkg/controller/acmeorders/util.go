@@ -19,6 +19,8 @@ package acmeorders
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -256,6 +258,11 @@ func partialChallengeSpecForAuthorization(ctx context.Context, issuer cmapi.Gene
 	}
 
 	if selectedSolver == nil || selectedChallenge == nil {
+		// Wildcard names can only be validated using DNS-01, so point users
+		// at the likely cause if only HTTP-01 solvers are configured.
+		if wc {
+			return nil, fmt.Errorf("no configured challenge solvers can be used for the wildcard domain %q, wildcard domains can only be validated using a DNS-01 solver", domainToFind)
+		}
 		return nil, fmt.Errorf("no configured challenge solvers can be used for this challenge")
 	}
 
@@ -355,6 +362,26 @@ func anyChallengesFailed(chs []*cmacme.Challenge) bool {
 	return false
 }
 
+// orderTimeoutReason builds the reason for an Order that did not complete
+// within the given timeout, including the reason each pending Challenge has
+// not yet been presented or accepted. Challenges in a final state are not
+// included.
+func orderTimeoutReason(timeout time.Duration, chs []*cmacme.Challenge) string {
+	var reasons []string
+	for _, ch := range chs {
+		if acme.IsFinalState(ch.Status.State) || ch.Status.Reason == "" {
+			continue
+		}
+		reasons = append(reasons, fmt.Sprintf("%s: %s", ch.Spec.DNSName, ch.Status.Reason))
+	}
+
+	reason := fmt.Sprintf("Order did not complete within %s", timeout)
+	if len(reasons) > 0 {
+		reason = fmt.Sprintf("%s: %s", reason, strings.Join(reasons, "; "))
+	}
+	return reason
+}
+
 func allChallengesFinal(chs []*cmacme.Challenge) bool {
 	for _, ch := range chs {
 		if !acme.IsFinalState(ch.Status.State) {
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/kr/pretty"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -204,6 +205,29 @@ func TestChallengeSpecForAuthorization(t *testing.T) {
 			},
 			expectedError: true,
 		},
+		"should return an error for a wildcard domain if only an HTTP01 solver is configured": {
+			acmeClient: basicACMEClient,
+			issuer: &cmapi.Issuer{
+				Spec: cmapi.IssuerSpec{
+					IssuerConfig: cmapi.IssuerConfig{
+						ACME: &cmacme.ACMEIssuer{
+							Solvers: []cmacme.ACMEChallengeSolver{emptySelectorSolverHTTP01},
+						},
+					},
+				},
+			},
+			order: &cmacme.Order{
+				Spec: cmacme.OrderSpec{
+					DNSNames: []string{"*.example.com"},
+				},
+			},
+			authz: &cmacme.ACMEAuthorization{
+				Identifier: "example.com",
+				Wildcard:   pointer.BoolPtr(true),
+				Challenges: []cmacme.ACMEChallenge{*acmeChallengeDNS01},
+			},
+			expectedError: true,
+		},
 		"should ignore HTTP01 override annotations if DNS01 solver is chosen": {
 			acmeClient: basicACMEClient,
 			issuer: &cmapi.Issuer{
@@ -1323,3 +1347,38 @@ func Test_ensureKeysForChallenges(t *testing.T) {
 		})
 	}
 }
+
+func Test_orderTimeoutReason(t *testing.T) {
+	tests := map[string]struct {
+		challenges []*cmacme.Challenge
+		want       string
+	}{
+		"no challenges": {
+			want: "Order did not complete within 1h0m0s",
+		},
+		"pending challenges without a reason are not listed": {
+			challenges: []*cmacme.Challenge{
+				gen.Challenge("foo", gen.SetChallengeDNSName("foo.com"), gen.SetChallengeState(cmacme.Pending)),
+			},
+			want: "Order did not complete within 1h0m0s",
+		},
+		"only the reasons of pending challenges are listed": {
+			challenges: []*cmacme.Challenge{
+				gen.Challenge("foo", gen.SetChallengeDNSName("foo.com"), gen.SetChallengeState(cmacme.Valid),
+					gen.SetChallengeReason("Successfully authorized domain")),
+				gen.Challenge("bar", gen.SetChallengeDNSName("bar.com"), gen.SetChallengeState(cmacme.Pending),
+					gen.SetChallengeReason("Waiting for HTTP-01 challenge propagation")),
+				gen.Challenge("baz", gen.SetChallengeDNSName("baz.com"),
+					gen.SetChallengeReason("Waiting for DNS-01 challenge propagation")),
+			},
+			want: "Order did not complete within 1h0m0s: bar.com: Waiting for HTTP-01 challenge propagation; baz.com: Waiting for DNS-01 challenge propagation",
+		},
+	}
+	for name, scenario := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := orderTimeoutReason(time.Hour, scenario.challenges); got != scenario.want {
+				t.Errorf("orderTimeoutReason() = %q, want %q", got, scenario.want)
+			}
+		})
+	}
+}
@@ -50,6 +50,11 @@ type controller struct {
 	certificateLister        cmlisters.CertificateLister
 	certificateRequestLister cmlisters.CertificateRequestLister
 	client                   cmclient.Interface
+
+	// defaultRevisionHistoryLimit is used for Certificates that do not set
+	// spec.revisionHistoryLimit. If zero, those Certificates are not garbage
+	// collected.
+	defaultRevisionHistoryLimit int
 }
 
 type revision struct {
@@ -84,12 +89,15 @@ func NewController(log logr.Logger, ctx *controllerpkg.Context) (*controller, wo
 		certificateLister:        certificateInformer.Lister(),
 		certificateRequestLister: certificateRequestInformer.Lister(),
 		client:                   ctx.CMClient,
+
+		defaultRevisionHistoryLimit: ctx.CertificateOptions.DefaultRevisionHistoryLimit,
 	}, queue, mustSync
 }
 
 // ProcessItem will attempt to garbage collect old CertificateRequests based
-// upon `spec.revisionHistoryLimit`. This controller will only act on
-// Certificates which are in a Ready state and this value is set.
+// upon `spec.revisionHistoryLimit`, falling back to the controller's default
+// revision history limit. This controller will only act on Certificates which
+// are in a Ready state and have a limit.
 func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	log := logf.FromContext(ctx).WithValues("key", key)
 
@@ -111,9 +119,13 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 
 	log = logf.WithResource(log, crt)
 
-	// If RevisionHistoryLimit is nil, don't attempt to garbage collect old
-	// CertificateRequests
-	if crt.Spec.RevisionHistoryLimit == nil {
+	// If RevisionHistoryLimit is nil and no default is configured, don't
+	// attempt to garbage collect old CertificateRequests
+	limit := c.defaultRevisionHistoryLimit
+	if crt.Spec.RevisionHistoryLimit != nil {
+		limit = int(*crt.Spec.RevisionHistoryLimit)
+	}
+	if limit <= 0 {
 		return nil
 	}
 
@@ -133,7 +145,6 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	}
 
 	// Fetch and delete all CertificateRequests that need to be deleted
-	limit := int(*crt.Spec.RevisionHistoryLimit)
 	toDelete := certificateRequestsToDelete(log, limit, requests)
 
 	for _, req := range toDelete {
@@ -61,6 +61,10 @@ func TestProcessItem(t *testing.T) {
 		// Request, if set, will exist in the apiserver before the test is run.
 		requests []runtime.Object
 
+		// defaultRevisionHistoryLimit is the controller's default limit for
+		// Certificates which do not set spec.revisionHistoryLimit.
+		defaultRevisionHistoryLimit int
+
 		expectedActions []testpkg.Action
 
 		// err is the expected error text returned by the controller, if any.
@@ -156,6 +160,42 @@ func TestProcessItem(t *testing.T) {
 				),
 			},
 		},
+		"delete 1 request if limit is not set, default limit is 1 and 2 requests exist": {
+			certificate: gen.CertificateFrom(baseCrt,
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue}),
+			),
+			defaultRevisionHistoryLimit: 1,
+			requests: []runtime.Object{
+				gen.CertificateRequestFrom(baseCR,
+					gen.SetCertificateRequestName("cr-1"),
+					gen.SetCertificateRequestRevision("1"),
+				),
+				gen.CertificateRequestFrom(baseCR,
+					gen.SetCertificateRequestName("cr-2"),
+					gen.SetCertificateRequestRevision("2"),
+				),
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewDeleteAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns", "cr-1")),
+			},
+		},
+		"prefer the Certificate's limit over the default limit": {
+			certificate: gen.CertificateFrom(baseCrt,
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue}),
+				gen.SetCertificateRevisionHistoryLimit(2),
+			),
+			defaultRevisionHistoryLimit: 1,
+			requests: []runtime.Object{
+				gen.CertificateRequestFrom(baseCR,
+					gen.SetCertificateRequestName("cr-1"),
+					gen.SetCertificateRequestRevision("1"),
+				),
+				gen.CertificateRequestFrom(baseCR,
+					gen.SetCertificateRequestName("cr-2"),
+					gen.SetCertificateRequestRevision("2"),
+				),
+			},
+		},
 		"delete 1 request if limit is 1 and 2 requests exist": {
 			certificate: gen.CertificateFrom(baseCrt,
 				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue}),
@@ -227,6 +267,7 @@ func TestProcessItem(t *testing.T) {
 			}
 			builder.CertManagerObjects = append(builder.CertManagerObjects, test.requests...)
 			builder.Init()
+			builder.Context.CertificateOptions.DefaultRevisionHistoryLimit = test.defaultRevisionHistoryLimit
 
 			// Register informers used by the controller using the registration wrapper
 			w := &controllerWrapper{}
@@ -44,6 +44,7 @@ import (
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 	"github.com/cert-manager/cert-manager/pkg/scheduler"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
@@ -70,6 +71,7 @@ type controller struct {
 	client                   cmclient.Interface
 	recorder                 record.EventRecorder
 	scheduledWorkQueue       scheduler.ScheduledWorkQueue
+	metrics                  *metrics.Metrics
 
 	// fieldManager is the string which will be used as the Field Manager on
 	// fields created or edited by the cert-manager Kubernetes client during
@@ -123,6 +125,7 @@ func NewController(
 		client:                   ctx.CMClient,
 		recorder:                 ctx.Recorder,
 		scheduledWorkQueue:       scheduler.NewScheduledWorkQueue(ctx.Clock, queue.Add),
+		metrics:                  ctx.Metrics,
 		fieldManager:             ctx.FieldManager,
 
 		// The following are used for testing purposes.
@@ -199,6 +202,7 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return err
 	}
 	c.recorder.Event(crt, corev1.EventTypeNormal, "Issuing", message)
+	c.metrics.IncrementCertificateIssuanceTriggeredCount(reason)
 
 	return nil
 }
@@ -179,6 +179,58 @@ type ACMEOptions struct {
 	// for ACME HTTP01 validations.
 	HTTP01SolverNameservers []string
 
+	// HTTP01SelfCheckAddress is the host and port that self-checks for ACME
+	// HTTP01 validations connect to instead of the challenge domain. The
+	// request is still sent with the challenge domain as its Host header. If
+	// empty, the challenge domain is connected to on port 80.
+	HTTP01SelfCheckAddress string
+
+	// HTTP01SelfCheckHost overrides the Host header of self-check requests
+	// for ACME HTTP01 validations. If empty, the challenge domain is used.
+	HTTP01SelfCheckHost string
+
+	// HTTP01SelfCheckFollowRedirects controls whether self-checks for ACME
+	// HTTP01 validations follow redirects returned by the solver. If false,
+	// a redirect fails the self-check.
+	HTTP01SelfCheckFollowRedirects bool
+
+	// OrderTimeout is the time after which an Order that is still waiting for
+	// its Challenges to complete is marked as failed, and its Challenges are
+	// cleaned up. Zero disables the timeout.
+	OrderTimeout time.Duration
+
+	// IssuerConditionReasonPrefix is prepended to the reason of every
+	// condition set on ACME Issuers and ClusterIssuers.
+	IssuerConditionReasonPrefix string
+
+	// IssuerCorrelationIDHeader is the name of the header in which a unique
+	// correlation ID is sent on every request made to the ACME server while
+	// setting up an ACME Issuer or ClusterIssuer. If empty, no correlation ID
+	// is sent.
+	IssuerCorrelationIDHeader string
+
+	// IssuerEventAnnotationKeys is a list of annotation keys whose values on
+	// ACME Issuers and ClusterIssuers are attached to the events emitted
+	// while setting them up.
+	IssuerEventAnnotationKeys []string
+
+	// IssuerClockSkewThreshold is the difference between the local clock and
+	// the clock of the ACME server above which a warning event is emitted
+	// while setting up ACME Issuers and ClusterIssuers. Zero disables the
+	// check.
+	IssuerClockSkewThreshold time.Duration
+
+	// IssuerBootstrapDeadline is the end of the grace period after the
+	// controller has started, during which transient errors while setting up
+	// ACME Issuers and ClusterIssuers set their Ready condition to Unknown
+	// rather than False. The zero value disables the grace period.
+	IssuerBootstrapDeadline time.Time
+
+	// IssuerHTTPTimeout is the maximum time that an individual HTTP request
+	// to an ACME server may take. Zero uses the default timeout of the ACME
+	// HTTP client.
+	IssuerHTTPTimeout time.Duration
+
 	// DNS01CheckAuthoritative is a flag for controlling if auth nss are used
 	// for checking propagation of an RR. This is the ideal scenario
 	DNS01CheckAuthoritative bool
@@ -212,12 +264,20 @@ type CertificateOptions struct {
 	// CopiedAnnotationPrefixes defines which annotations should be copied
 	// Certificate -> CertificateRequest, CertificateRequest -> Order.
 	CopiedAnnotationPrefixes []string
+	// DefaultRevisionHistoryLimit is the revision history limit applied to
+	// Certificates that do not set spec.revisionHistoryLimit. If zero, no
+	// default limit is applied.
+	DefaultRevisionHistoryLimit int
 }
 
 type SchedulerOptions struct {
 	// MaxConcurrentChallenges determines the maximum number of challenges that can be
 	// scheduled as 'processing' at once.
 	MaxConcurrentChallenges int
+	// MaxConcurrentChallengesPerDNSProvider determines the maximum number of
+	// DNS01 challenges for a single DNS provider that can be scheduled as
+	// 'processing' at once. If zero, no per-provider limit is applied.
+	MaxConcurrentChallengesPerDNSProvider int
 }
 
 // ContextFactory is used for constructing new Contexts who's clients have been
@@ -21,15 +21,19 @@ import (
 	"reflect"
 	"runtime/debug"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgotesting "k8s.io/client-go/testing"
 
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
+	issuerfake "github.com/cert-manager/cert-manager/pkg/issuer/fake"
 )
 
 func newFakeIssuerWithStatus(name string, status v1.IssuerStatus) *v1.Issuer {
@@ -95,6 +99,57 @@ func TestUpdateIssuerStatus(t *testing.T) {
 	assertDeepEqual(t, errorf, newStatus, issuer.Status)
 }
 
+func TestSyncUnchanged(t *testing.T) {
+	b := &testpkg.Builder{
+		T: t,
+	}
+	b.Init()
+	defer b.Stop()
+
+	c := &controller{}
+
+	_, _, err := c.Register(b.Context)
+	require.NoError(t, err)
+
+	// Setup computes the same Ready condition on every reconcile.
+	c.issuerFactory = &issuerfake.Factory{
+		IssuerForFunc: func(iss v1.GenericIssuer) (issuer.Interface, error) {
+			return &issuerfake.Issuer{
+				SetupFunc: func(context.Context) error {
+					apiutil.SetIssuerCondition(iss, iss.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionTrue, "Ready", "ready")
+					return nil
+				},
+			}, nil
+		},
+	}
+
+	b.Start()
+
+	cmClient := b.FakeCMClient()
+
+	transitionTime := metav1.NewTime(time.Unix(1000, 0))
+	originalIssuer := newFakeIssuerWithStatus("test", v1.IssuerStatus{
+		Conditions: []v1.IssuerCondition{
+			{
+				Type:               v1.IssuerConditionReady,
+				Status:             cmmeta.ConditionTrue,
+				Reason:             "Ready",
+				Message:            "ready",
+				LastTransitionTime: &transitionTime,
+			},
+		},
+	})
+
+	iss, err := cmClient.CertmanagerV1().Issuers("testns").Create(context.TODO(), originalIssuer, metav1.CreateOptions{})
+	assertErrIsNil(t, fatalf, err)
+	assertNumberOfActions(t, fatalf, filter(cmClient.Actions()), 1)
+
+	// A reconcile that does not change the status must not write.
+	err = c.Sync(context.TODO(), iss)
+	assertErrIsNil(t, fatalf, err)
+	assertNumberOfActions(t, fatalf, filter(cmClient.Actions()), 1)
+}
+
 func assertIsUpdateAction(t *testing.T, f failfFunc, action clientgotesting.Action) clientgotesting.UpdateAction {
 	updateAction, ok := action.(clientgotesting.UpdateAction)
 	if !ok {
@@ -28,6 +28,9 @@ import (
 // thrown away in this case.
 type FakeRecorder struct {
 	Events []string
+	// Annotations contains the annotations of every event recorded with
+	// AnnotatedEventf, in the order they were recorded.
+	Annotations []map[string]string
 }
 
 func (f *FakeRecorder) Event(object runtime.Object, eventtype, reason, message string) {
@@ -42,5 +45,6 @@ func (f *FakeRecorder) PastEventf(object runtime.Object, timestamp metav1.Time,
 }
 
 func (f *FakeRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
-	f.Eventf(object, eventtype, reason, messageFmt, args)
+	f.Annotations = append(f.Annotations, annotations)
+	f.Eventf(object, eventtype, reason, messageFmt, args...)
 }
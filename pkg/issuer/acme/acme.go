@@ -20,9 +20,14 @@ import (
 	"context"
 	"crypto"
 	"fmt"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
 	core "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
 
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
@@ -31,6 +36,7 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/issuer"
 	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/pkg/util/errors"
 	"github.com/cert-manager/cert-manager/pkg/util/kube"
 )
 
@@ -47,6 +53,10 @@ type Acme struct {
 	// It can be stubbed in unit tests.
 	keyFromSecret keyFromSecretFunc
 
+	// secretReadBackoff is the backoff used to retry reading the account key
+	// secret when a transient error occurs.
+	secretReadBackoff wait.Backoff
+
 	// clientBuilder builds a new ACME client.
 	clientBuilder accounts.NewClientFunc
 
@@ -60,6 +70,35 @@ type Acme struct {
 
 	// userAgent is the string used as the UserAgent when making HTTP calls.
 	userAgent string
+
+	// conditionReasonPrefix is prepended to the reason of the Ready condition
+	// set on the issuer.
+	conditionReasonPrefix string
+
+	// correlationIDHeader is the name of the header in which a unique
+	// correlation ID is sent on every request made during Setup. If empty,
+	// no correlation ID is sent.
+	correlationIDHeader string
+
+	// eventAnnotationKeys is a list of annotation keys whose values on the
+	// issuer are attached to the events emitted during Setup.
+	eventAnnotationKeys []string
+
+	// clock is used to measure the clock skew between the local clock and the
+	// ACME server.
+	clock clock.Clock
+	// clockSkewThreshold is the clock skew above which a warning event is
+	// emitted during Setup. Zero disables the check.
+	clockSkewThreshold time.Duration
+
+	// bootstrapDeadline is the end of the bootstrap grace period, during
+	// which transient errors in Setup set the Ready condition to Unknown
+	// rather than False. The zero value disables the grace period.
+	bootstrapDeadline time.Time
+
+	// httpTimeout is the maximum time that an individual HTTP request to the
+	// ACME server may take. Zero uses the default timeout of the HTTP client.
+	httpTimeout time.Duration
 }
 
 // New returns a new ACME issuer interface for the given issuer.
@@ -73,6 +112,7 @@ func New(ctx *controller.Context, issuer v1.GenericIssuer) (issuer.Interface, er
 	a := &Acme{
 		issuer:                   issuer,
 		keyFromSecret:            newKeyFromSecret(secretsLister),
+		secretReadBackoff:        defaultSecretReadBackoff,
 		clientBuilder:            accounts.NewClient,
 		secretsClient:            ctx.Client.CoreV1(),
 		recorder:                 ctx.Recorder,
@@ -80,11 +120,29 @@ func New(ctx *controller.Context, issuer v1.GenericIssuer) (issuer.Interface, er
 		accountRegistry:          ctx.ACMEOptions.AccountRegistry,
 		metrics:                  ctx.Metrics,
 		userAgent:                ctx.RESTConfig.UserAgent,
+		conditionReasonPrefix:    ctx.ACMEOptions.IssuerConditionReasonPrefix,
+		correlationIDHeader:      ctx.ACMEOptions.IssuerCorrelationIDHeader,
+		eventAnnotationKeys:      ctx.ACMEOptions.IssuerEventAnnotationKeys,
+		clock:                    ctx.Clock,
+		clockSkewThreshold:       ctx.ACMEOptions.IssuerClockSkewThreshold,
+		bootstrapDeadline:        ctx.ACMEOptions.IssuerBootstrapDeadline,
+		httpTimeout:              ctx.ACMEOptions.IssuerHTTPTimeout,
 	}
 
 	return a, nil
 }
 
+// defaultSecretReadBackoff is the default backoff used to retry reading the
+// account key secret when a transient error occurs. The total time spent
+// retrying is kept short, so that a persistently failing apiserver still
+// results in the issuer being re-queued.
+var defaultSecretReadBackoff = wait.Backoff{
+	Steps:    4,
+	Duration: 200 * time.Millisecond,
+	Factor:   2.0,
+	Jitter:   0.1,
+}
+
 // keyFromSecretFunc accepts name, namespace and keyName for secret, verifies
 // and returns a private key stored at keyName.
 type keyFromSecretFunc func(ctx context.Context, namespace, name, keyName string) (crypto.Signer, error)
@@ -92,10 +150,39 @@ type keyFromSecretFunc func(ctx context.Context, namespace, name, keyName string
 // newKeyFromSecret returns an implementation of keyFromSecretFunc for a secrets lister.
 func newKeyFromSecret(secretLister internalinformers.SecretLister) keyFromSecretFunc {
 	return func(ctx context.Context, namespace, name, keyName string) (crypto.Signer, error) {
-		return kube.SecretTLSKeyRef(ctx, secretLister, namespace, name, keyName)
+		secret, err := secretLister.Secrets(namespace).Get(name)
+		if err != nil {
+			return nil, err
+		}
+
+		// Check the type of the Secret before attempting to parse it, so that
+		// a Secret of the wrong type is distinguished from one whose contents
+		// cannot be parsed.
+		if incompatibleAccountKeySecretTypes.Has(string(secret.Type)) {
+			return nil, errors.NewInvalidSecretType("secret '%s/%s' is of type %q, which cannot hold an ACME account private key", namespace, name, secret.Type)
+		}
+
+		key, _, err := kube.ParseTLSKeyFromSecret(secret, keyName)
+		if err != nil {
+			return nil, err
+		}
+
+		return key, nil
 	}
 }
 
+// incompatibleAccountKeySecretTypes are the built-in Secret types that are
+// validated by the apiserver to hold a structure other than a private key.
+// Opaque, TLS and custom Secret types may hold the ACME account private key.
+var incompatibleAccountKeySecretTypes = sets.NewString(
+	string(corev1.SecretTypeServiceAccountToken),
+	string(corev1.SecretTypeDockercfg),
+	string(corev1.SecretTypeDockerConfigJson),
+	string(corev1.SecretTypeBasicAuth),
+	string(corev1.SecretTypeSSHAuth),
+	string(corev1.SecretTypeBootstrapToken),
+)
+
 // Register this Issuer with the issuer factory
 func init() {
 	issuer.RegisterIssuer(apiutil.IssuerACME, New)
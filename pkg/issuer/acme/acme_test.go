@@ -0,0 +1,97 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/cert-manager/cert-manager/pkg/util/errors"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+func TestNewKeyFromSecret(t *testing.T) {
+	pk, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkBytes := pki.EncodePKCS1PrivateKey(pk)
+
+	tests := map[string]struct {
+		secretType     corev1.SecretType
+		data           map[string][]byte
+		expSecretType  bool
+		expInvalidData bool
+	}{
+		"Opaque secret with a valid key is parsed": {
+			secretType: corev1.SecretTypeOpaque,
+			data:       map[string][]byte{corev1.TLSPrivateKeyKey: pkBytes},
+		},
+		"secret without a type with a valid key is parsed": {
+			data: map[string][]byte{corev1.TLSPrivateKeyKey: pkBytes},
+		},
+		"TLS secret with a valid key is parsed": {
+			secretType: corev1.SecretTypeTLS,
+			data:       map[string][]byte{corev1.TLSPrivateKeyKey: pkBytes},
+		},
+		"Opaque secret with invalid data is reported as invalid data": {
+			secretType:     corev1.SecretTypeOpaque,
+			data:           map[string][]byte{corev1.TLSPrivateKeyKey: []byte("invalid")},
+			expInvalidData: true,
+		},
+		"dockerconfigjson secret is reported as having an invalid type": {
+			secretType:    corev1.SecretTypeDockerConfigJson,
+			data:          map[string][]byte{corev1.DockerConfigJsonKey: []byte("{}")},
+			expSecretType: true,
+		},
+		"service account token secret is reported as having an invalid type": {
+			secretType:    corev1.SecretTypeServiceAccountToken,
+			expSecretType: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			if err := indexer.Add(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-ns", Name: "test-secret"},
+				Type:       test.secretType,
+				Data:       test.data,
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			kfs := newKeyFromSecret(corelisters.NewSecretLister(indexer))
+			key, err := kfs(context.Background(), "test-ns", "test-secret", corev1.TLSPrivateKeyKey)
+
+			if errors.IsInvalidSecretType(err) != test.expSecretType {
+				t.Errorf("expected invalid secret type error: %v, got: %v", test.expSecretType, err)
+			}
+			if errors.IsInvalidData(err) != test.expInvalidData {
+				t.Errorf("expected invalid data error: %v, got: %v", test.expInvalidData, err)
+			}
+			if !test.expSecretType && !test.expInvalidData && key == nil {
+				t.Errorf("expected a key to be returned, got error: %v", err)
+			}
+		})
+	}
+}
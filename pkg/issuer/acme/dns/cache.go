@@ -0,0 +1,127 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/cache"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/acmedns"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/azuredns"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/clouddns"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/cloudflare"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/digitalocean"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/route53"
+)
+
+// providerCacheTTL is how long a constructed DNS provider is reused for.
+// Entries for rotated credentials are never looked up again and expire after
+// this time, and providers using ambient credentials are periodically rebuilt.
+const providerCacheTTL = 30 * time.Minute
+
+// providerCache caches DNS providers keyed by the provider name and the
+// arguments, including credentials, that they were constructed with. This
+// allows many challenges that use the same solver configuration to share a
+// single API client instead of constructing and authenticating a new one per
+// challenge.
+type providerCache struct {
+	cache *cache.Expiring
+	ttl   time.Duration
+}
+
+func newProviderCache(ttl time.Duration) *providerCache {
+	return &providerCache{
+		cache: cache.NewExpiring(),
+		ttl:   ttl,
+	}
+}
+
+// providerCacheKey returns the cache key for a provider constructed with the
+// given arguments. The arguments are hashed so that credentials are not held
+// in the keys of the cache.
+func providerCacheKey(name string, args ...interface{}) (string, error) {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return name + "/" + hex.EncodeToString(sum[:]), nil
+}
+
+// cachedProvider returns the provider stored in the cache for the given name
+// and arguments, or constructs and stores a new one. Errors returned when
+// constructing a provider are not cached.
+func cachedProvider[T any](c *providerCache, name string, construct func() (T, error), args ...interface{}) (T, error) {
+	key, err := providerCacheKey(name, args...)
+	if err != nil {
+		// Arguments that cannot be hashed cannot be cached either.
+		return construct()
+	}
+
+	if p, ok := c.cache.Get(key); ok {
+		return p.(T), nil
+	}
+
+	p, err := construct()
+	if err != nil {
+		return p, err
+	}
+	c.cache.Set(key, p, c.ttl)
+	return p, nil
+}
+
+// wrap returns a set of constructors that look up providers in the cache
+// before falling back to the given constructors.
+func (c *providerCache) wrap(d dnsProviderConstructors) dnsProviderConstructors {
+	return dnsProviderConstructors{
+		cloudDNS: func(project string, serviceAccount []byte, dns01Nameservers []string, ambient bool, hostedZoneName string) (*clouddns.DNSProvider, error) {
+			return cachedProvider(c, "clouddns", func() (*clouddns.DNSProvider, error) {
+				return d.cloudDNS(project, serviceAccount, dns01Nameservers, ambient, hostedZoneName)
+			}, project, serviceAccount, dns01Nameservers, ambient, hostedZoneName)
+		},
+		cloudFlare: func(email, apikey, apiToken string, dns01Nameservers []string, userAgent string) (*cloudflare.DNSProvider, error) {
+			return cachedProvider(c, "cloudflare", func() (*cloudflare.DNSProvider, error) {
+				return d.cloudFlare(email, apikey, apiToken, dns01Nameservers, userAgent)
+			}, email, apikey, apiToken, dns01Nameservers, userAgent)
+		},
+		route53: func(accessKey, secretKey, hostedZoneID, region, role string, ambient bool, dns01Nameservers []string, userAgent string) (*route53.DNSProvider, error) {
+			return cachedProvider(c, "route53", func() (*route53.DNSProvider, error) {
+				return d.route53(accessKey, secretKey, hostedZoneID, region, role, ambient, dns01Nameservers, userAgent)
+			}, accessKey, secretKey, hostedZoneID, region, role, ambient, dns01Nameservers, userAgent)
+		},
+		azureDNS: func(environment, clientID, clientSecret, subscriptionID, tenantID, resourceGroupName, hostedZoneName string, dns01Nameservers []string, ambient bool, managedIdentity *cmacme.AzureManagedIdentity) (*azuredns.DNSProvider, error) {
+			return cachedProvider(c, "azuredns", func() (*azuredns.DNSProvider, error) {
+				return d.azureDNS(environment, clientID, clientSecret, subscriptionID, tenantID, resourceGroupName, hostedZoneName, dns01Nameservers, ambient, managedIdentity)
+			}, environment, clientID, clientSecret, subscriptionID, tenantID, resourceGroupName, hostedZoneName, dns01Nameservers, ambient, managedIdentity)
+		},
+		acmeDNS: func(host string, accountJson []byte, dns01Nameservers []string) (*acmedns.DNSProvider, error) {
+			return cachedProvider(c, "acmedns", func() (*acmedns.DNSProvider, error) {
+				return d.acmeDNS(host, accountJson, dns01Nameservers)
+			}, host, accountJson, dns01Nameservers)
+		},
+		digitalOcean: func(token string, dns01Nameservers []string, userAgent string) (*digitalocean.DNSProvider, error) {
+			return cachedProvider(c, "digitalocean", func() (*digitalocean.DNSProvider, error) {
+				return d.digitalOcean(token, dns01Nameservers, userAgent)
+			}, token, dns01Nameservers, userAgent)
+		},
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/digitalocean"
+)
+
+func TestProviderCache(t *testing.T) {
+	var constructed int
+	var constructErr error
+	c := newProviderCache(time.Hour)
+	constructors := c.wrap(dnsProviderConstructors{
+		digitalOcean: func(token string, dns01Nameservers []string, userAgent string) (*digitalocean.DNSProvider, error) {
+			constructed++
+			if constructErr != nil {
+				return nil, constructErr
+			}
+			return &digitalocean.DNSProvider{}, nil
+		},
+	})
+
+	first, err := constructors.digitalOcean("token", []string{"8.8.8.8:53"}, "cert-manager")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := constructors.digitalOcean("token", []string{"8.8.8.8:53"}, "cert-manager")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if constructed != 1 || first != second {
+		t.Errorf("expected the provider to be constructed once and reused, constructed %d times", constructed)
+	}
+
+	if _, err := constructors.digitalOcean("rotated-token", []string{"8.8.8.8:53"}, "cert-manager"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if constructed != 2 {
+		t.Errorf("expected a new provider to be constructed for different credentials, constructed %d times", constructed)
+	}
+
+	constructErr = errors.New("invalid token")
+	for i := 0; i < 2; i++ {
+		if _, err := constructors.digitalOcean("invalid-token", nil, "cert-manager"); err == nil {
+			t.Errorf("expected an error to be returned")
+		}
+	}
+	if constructed != 4 {
+		t.Errorf("expected providers that failed to be constructed not to be cached, constructed %d times", constructed)
+	}
+}
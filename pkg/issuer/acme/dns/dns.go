@@ -512,14 +512,14 @@ func NewSolver(ctx *controller.Context) (*Solver, error) {
 	return &Solver{
 		Context:      ctx,
 		secretLister: ctx.KubeSharedInformerFactory.Secrets().Lister(),
-		dnsProviderConstructors: dnsProviderConstructors{
+		dnsProviderConstructors: newProviderCache(providerCacheTTL).wrap(dnsProviderConstructors{
 			clouddns.NewDNSProvider,
 			cloudflare.NewDNSProviderCredentials,
 			route53.NewDNSProvider,
 			azuredns.NewDNSProviderCredentials,
 			acmedns.NewDNSProviderHostBytes,
 			digitalocean.NewDNSProviderCredentials,
-		},
+		}),
 		webhookSolvers: initialized,
 	}, nil
 }
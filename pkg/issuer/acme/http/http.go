@@ -68,7 +68,22 @@ type Solver struct {
 	requiredPasses   int
 }
 
-type reachabilityTest func(ctx context.Context, url *url.URL, key string, dnsServers []string, userAgent string) error
+type reachabilityTest func(ctx context.Context, url *url.URL, key string, dnsServers []string, selfCheck selfCheckOptions, userAgent string) error
+
+// selfCheckOptions configures how a reachability test reaches the solver.
+type selfCheckOptions struct {
+	// address is the host and port to connect to instead of the challenge
+	// domain. If empty, the challenge domain is connected to.
+	address string
+
+	// host is the Host header to send instead of the challenge domain. If
+	// empty, the challenge domain is sent.
+	host string
+
+	// followRedirects controls whether redirects returned by the solver are
+	// followed. If false, a redirect fails the reachability test.
+	followRedirects bool
+}
 
 // NewSolver returns a new ACME HTTP01 solver for the given *controller.Context.
 func NewSolver(ctx *controller.Context) (*Solver, error) {
@@ -159,9 +174,15 @@ func (s *Solver) Check(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.
 	log = log.WithValues("url", url)
 	ctx = logf.NewContext(ctx, log)
 
+	selfCheck := selfCheckOptions{
+		address:         s.HTTP01SelfCheckAddress,
+		host:            s.HTTP01SelfCheckHost,
+		followRedirects: s.HTTP01SelfCheckFollowRedirects,
+	}
+
 	log.V(logf.DebugLevel).Info("running self check multiple times to ensure challenge has propagated", "required_passes", s.requiredPasses)
 	for i := 0; i < s.requiredPasses; i++ {
-		err := s.testReachability(ctx, url, ch.Spec.Key, s.HTTP01SolverNameservers, s.Context.RESTConfig.UserAgent)
+		err := s.testReachability(ctx, url, ch.Spec.Key, s.HTTP01SolverNameservers, selfCheck, s.Context.RESTConfig.UserAgent)
 		if err != nil {
 			return err
 		}
@@ -199,8 +220,11 @@ func (s *Solver) buildChallengeUrl(ch *cmacme.Challenge) *url.URL {
 }
 
 // testReachability will attempt to connect to the 'domain' with 'path' and
-// check if the returned body equals 'key'
-func testReachability(ctx context.Context, url *url.URL, key string, dnsServers []string, userAgent string) error {
+// check if the returned body equals 'key'. If a self check address is set, the
+// connection to the 'domain' is made to that address instead, while the
+// request is still sent with the 'domain', or the self check host if set, as
+// its Host header.
+func testReachability(ctx context.Context, url *url.URL, key string, dnsServers []string, selfCheck selfCheckOptions, userAgent string) error {
 	log := logf.FromContext(ctx)
 	log.V(logf.DebugLevel).Info("performing HTTP01 reachability check")
 
@@ -209,6 +233,9 @@ func testReachability(ctx context.Context, url *url.URL, key string, dnsServers
 		return err
 	}
 	req.Header.Set("User-Agent", userAgent)
+	if len(selfCheck.host) != 0 {
+		req.Host = selfCheck.host
+	}
 
 	// The ACME spec says that a verifier should try on http port 80 first, but to follow any
 	// redirects which may be returned. Let's Encrypt, in practice, follows redirects for HTTP
@@ -258,8 +285,9 @@ func testReachability(ctx context.Context, url *url.URL, key string, dnsServers
 		},
 	}
 
+	dialContext := (&net.Dialer{}).DialContext
 	if len(dnsServers) != 0 {
-		transport.DialContext = func(ctx context.Context, network, addr string) (conn net.Conn, err error) {
+		dialContext = func(ctx context.Context, network, addr string) (conn net.Conn, err error) {
 			// we need to increment a counter to iterate through the dns servers as the dialer will not
 			// return an error if the dns server is not responding.
 			counter := 0
@@ -280,10 +308,32 @@ func testReachability(ctx context.Context, url *url.URL, key string, dnsServers
 			return dialer.DialContext(ctx, network, addr)
 		}
 	}
+	transport.DialContext = dialContext
+	if len(selfCheck.address) != 0 {
+		// Only the connection for the challenge URL itself is redirected, any
+		// redirects it returns are followed as usual.
+		challengeAddr := net.JoinHostPort(url.Hostname(), "80")
+		if url.Port() != "" {
+			challengeAddr = net.JoinHostPort(url.Hostname(), url.Port())
+		}
+		transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			if addr == challengeAddr {
+				addr = selfCheck.address
+			}
+			return dialContext(ctx, network, addr)
+		}
+	}
 	client := &http.Client{
 		Transport: transport,
 		Timeout:   time.Second * 10,
 	}
+	if !selfCheck.followRedirects {
+		// Return the redirect response itself, which then fails the status
+		// code check below.
+		client.CheckRedirect = func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+	}
 
 	response, err := client.Do(req)
 	if err != nil {
@@ -20,6 +20,8 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"strings"
 	"sync/atomic"
@@ -35,9 +37,9 @@ import (
 // countReachabilityTestCalls is a wrapper function that allows us to count the number
 // of calls to a reachabilityTest.
 func countReachabilityTestCalls(counter *int, t reachabilityTest) reachabilityTest {
-	return func(ctx context.Context, url *url.URL, key string, dnsServers []string, userAgent string) error {
+	return func(ctx context.Context, url *url.URL, key string, dnsServers []string, selfCheck selfCheckOptions, userAgent string) error {
 		*counter++
-		return t(ctx, url, key, dnsServers, userAgent)
+		return t(ctx, url, key, dnsServers, selfCheck, userAgent)
 	}
 }
 
@@ -51,14 +53,14 @@ func TestCheck(t *testing.T) {
 	tests := []testT{
 		{
 			name: "should pass",
-			reachabilityTest: func(context.Context, *url.URL, string, []string, string) error {
+			reachabilityTest: func(context.Context, *url.URL, string, []string, selfCheckOptions, string) error {
 				return nil
 			},
 			expectedErr: false,
 		},
 		{
 			name: "should error",
-			reachabilityTest: func(context.Context, *url.URL, string, []string, string) error {
+			reachabilityTest: func(context.Context, *url.URL, string, []string, selfCheckOptions, string) error {
 				return fmt.Errorf("failed")
 			},
 			expectedErr: true,
@@ -176,7 +178,7 @@ func TestReachabilityCustomDnsServers(t *testing.T) {
 
 	for _, tt := range tests {
 		atomic.StoreInt32(&dnsServerCalled, 0)
-		err = testReachability(context.Background(), u, key, tt.dnsServers, "cert-manager-test")
+		err = testReachability(context.Background(), u, key, tt.dnsServers, selfCheckOptions{followRedirects: true}, "cert-manager-test")
 		switch {
 		case err == nil:
 			t.Errorf("Expected error for testReachability, but got none")
@@ -190,3 +192,69 @@ func TestReachabilityCustomDnsServers(t *testing.T) {
 		}
 	}
 }
+
+func TestReachabilitySelfCheck(t *testing.T) {
+	key := "the key"
+	// gotHost is the Host header of the last request for the challenge token.
+	var gotHost string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/acme-challenge/redirect":
+			http.Redirect(w, r, "/.well-known/acme-challenge/token", http.StatusFound)
+		case "/.well-known/acme-challenge/token":
+			gotHost = r.Host
+			fmt.Fprint(w, key)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	tests := map[string]struct {
+		path      string
+		selfCheck selfCheckOptions
+		expHost   string
+		expErr    bool
+	}{
+		"the self check address is connected to with the challenge domain as the Host header": {
+			path:      "/.well-known/acme-challenge/token",
+			selfCheck: selfCheckOptions{address: server.Listener.Addr().String()},
+			expHost:   "example.invalid",
+		},
+		"the self check host is sent as the Host header": {
+			path:      "/.well-known/acme-challenge/token",
+			selfCheck: selfCheckOptions{address: server.Listener.Addr().String(), host: "ingress.example.com"},
+			expHost:   "ingress.example.com",
+		},
+		"redirects are followed if enabled": {
+			path:      "/.well-known/acme-challenge/redirect",
+			selfCheck: selfCheckOptions{address: server.Listener.Addr().String(), followRedirects: true},
+			expHost:   "example.invalid",
+		},
+		"redirects are followed with the self check host if enabled": {
+			path:      "/.well-known/acme-challenge/redirect",
+			selfCheck: selfCheckOptions{address: server.Listener.Addr().String(), host: "ingress.example.com", followRedirects: true},
+			expHost:   "ingress.example.com",
+		},
+		"redirects fail the self check if disabled": {
+			path:      "/.well-known/acme-challenge/redirect",
+			selfCheck: selfCheckOptions{address: server.Listener.Addr().String()},
+			expErr:    true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotHost = ""
+
+			u := &url.URL{Scheme: "http", Host: "example.invalid", Path: test.path}
+			err := testReachability(context.Background(), u, key, nil, test.selfCheck, "cert-manager-test")
+			if (err != nil) != test.expErr {
+				t.Fatalf("Expected error: %v, but got: %v", test.expErr, err)
+			}
+			if !test.expErr && gotHost != test.expHost {
+				t.Errorf("Expected Host header %q, but got %q", test.expHost, gotHost)
+			}
+		})
+	}
+}
@@ -18,25 +18,34 @@ package acme
 
 import (
 	"context"
+	"crypto"
 	"crypto/rsa"
 	"encoding/base64"
 	"fmt"
 	"net/url"
+	"reflect"
 	"strings"
+	"unicode"
 
 	acmeapi "golang.org/x/crypto/acme"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/util/retry"
 
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	"github.com/cert-manager/cert-manager/pkg/acme"
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
 	"github.com/cert-manager/cert-manager/pkg/acme/client"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/util/errors"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
@@ -44,30 +53,51 @@ const (
 	errorAccountRegistrationFailed = "ErrRegisterACMEAccount"
 	errorAccountVerificationFailed = "ErrVerifyACMEAccount"
 	errorAccountUpdateFailed       = "ErrUpdateACMEAccount"
+	errorAccountEABRejected        = "ErrACMEEABRejected"
+	errorEmailRequired             = "ErrACMEEmailRequired"
+	errorAccountKeySecretType      = "ErrACMEAccountKeySecretType"
+	errorTermsOfServiceNotAccepted = "ErrACMETermsOfServiceNotAccepted"
 	errorInvalidConfig             = "InvalidConfig"
 	errorInvalidURL                = "InvalidURL"
 
 	successAccountRegistered = "ACMEAccountRegistered"
+	successAccountExisting   = "ACMEAccountExisting"
 	successAccountVerified   = "ACMEAccountVerified"
 
+	reasonBootstrapping = "ACMEBootstrapping"
+
+	warningDeprecation           = "ACMEDeprecationWarning"
+	warningClockSkew             = "ACMEClockSkew"
+	warningTermsOfServiceChanged = "ACMETermsOfServiceChanged"
+
 	messageAccountRegistrationFailed     = "Failed to register ACME account: "
 	messageAccountVerificationFailed     = "Failed to verify ACME account: "
 	messageAccountUpdateFailed           = "Failed to update ACME account:"
+	messageAccountEABRejected            = "The External Account Binding was rejected: "
 	messageAccountRegistered             = "The ACME account was registered with the ACME server"
 	messageAccountVerified               = "The ACME account was verified with the ACME server"
+	messageAccountCreated                = "A new ACME account was created on the ACME server"
+	messageAccountExisting               = "An existing ACME account was found on the ACME server for the account private key"
 	messageNoSecretKeyGenerationDisabled = "the ACME issuer config has 'disableAccountKeyGeneration' set to true, but the secret was not found: "
 	messageInvalidPrivateKey             = "Account private key is invalid: "
-
-	messageTemplateUpdateToV2              = "Your ACME server URL is set to a v1 endpoint (%s). You should update the spec.acme.server field to %q"
-	messageTemplateNotRSA                  = "ACME private key in %q is not of type RSA"
-	messageTemplateFailedToParseURL        = "Failed to parse existing ACME server URI %q: %v"
-	messageTemplateFailedToParseAccountURL = "Failed to parse existing ACME account URI %q: %v"
-	messageTemplateFailedToGetEABKey       = "failed to get External Account Binding key from secret: %v"
+	messageAccountKeySecretType          = "Account private key Secret has an incompatible type: "
+	messageBootstrapping                 = "The controller has recently started, retrying after a transient error: "
+	messageEmailRequired                 = "the ACME issuer config has 'requireEmail' set to true, but no email address is configured, so no ACME account will be registered"
+
+	messageTemplateUpdateToV2                = "Your ACME server URL is set to a v1 endpoint (%s). You should update the spec.acme.server field to %q"
+	messageTemplateNotRSA                    = "ACME private key in %q is not of type RSA"
+	messageTemplateFailedToParseURL          = "Failed to parse existing ACME server URI %q: %v"
+	messageTemplateFailedToParseAccountURL   = "Failed to parse existing ACME account URI %q: %v"
+	messageTemplateFailedToGetEABKey         = "failed to get External Account Binding key from secret: %v"
+	messageTemplateDeprecationWarning        = "The ACME server returned a deprecation warning: %s"
+	messageTemplateClockSkew                 = "The local clock differs from the ACME server's clock by %s, which exceeds the threshold of %s. Clock skew may cause requests to the ACME server to fail"
+	messageTemplateTermsOfServiceNotAccepted = "The ACME server's terms of service at %q have not been accepted, as the issuer's accepted terms of service URL is %q"
+	messageTemplateTermsOfServiceChanged     = "The ACME server has published new terms of service at %q, the ACME account was registered under the terms of service at %q"
 )
 
 // Setup will verify an existing ACME registration, or create one if not
 // already registered.
-func (a *Acme) Setup(ctx context.Context) error {
+func (a *Acme) Setup(ctx context.Context) (setupErr error) {
 	log := logf.FromContext(ctx)
 
 	// Correct reason and message for issuer's Ready condition must be always set
@@ -75,11 +105,21 @@ func (a *Acme) Setup(ctx context.Context) error {
 	status := cmmeta.ConditionFalse
 	var reason, msg string
 	defer func() {
+		// Errors that are returned will be retried, and are likely to be
+		// transient shortly after the controller has started, so they do not
+		// mark the issuer as not ready during the bootstrap grace period.
+		if status == cmmeta.ConditionFalse && setupErr != nil &&
+			!a.bootstrapDeadline.IsZero() && a.clock.Now().Before(a.bootstrapDeadline) {
+			status = cmmeta.ConditionUnknown
+			reason = reasonBootstrapping
+			msg = messageBootstrapping + msg
+		}
+
 		apiutil.SetIssuerCondition(a.issuer,
 			a.issuer.GetGeneration(),
 			v1.IssuerConditionReady,
 			status,
-			reason,
+			a.conditionReasonPrefix+reason,
 			msg)
 	}()
 
@@ -91,6 +131,14 @@ func (a *Acme) Setup(ctx context.Context) error {
 		return nil
 	}
 
+	// check whether a contact email is required before making any requests.
+	if a.issuer.GetSpec().ACME.RequireEmail && a.issuer.GetSpec().ACME.Email == "" {
+		reason = errorEmailRequired
+		msg = messageEmailRequired
+		// Return nil, because we do not want to re-queue an Issuer with an invalid spec.
+		return nil
+	}
+
 	// if the namespace field is not set, we are working on a ClusterIssuer resource
 	// therefore we should check for the ACME private key in the 'cluster resource namespace'.
 	ns := a.issuer.GetObjectMeta().Namespace
@@ -101,22 +149,33 @@ func (a *Acme) Setup(ctx context.Context) error {
 	log = logf.WithRelatedResourceName(log, a.issuer.GetSpec().ACME.PrivateKey.Name, ns, "Secret")
 
 	// attempt to obtain the existing private key from the apiserver.
+	// if a transient error occurs, retry reading the secret with a backoff.
 	// if it does not exist then we generate one
 	// if it contains invalid data, warn the user and return without error.
 	// if any other error occurs, return it and retry.
 	privateKeySelector := acme.PrivateKeySelector(a.issuer.GetSpec().ACME.PrivateKey)
-	pk, err := a.keyFromSecret(ctx, ns, privateKeySelector.Name, privateKeySelector.Key)
+	var pk crypto.Signer
+	err := retry.OnError(a.secretReadBackoff, isTransientSecretReadError, func() error {
+		var err error
+		pk, err = a.keyFromSecret(ctx, ns, privateKeySelector.Name, privateKeySelector.Key)
+		if isTransientSecretReadError(err) {
+			log.V(logf.DebugLevel).Info("transient error reading the ACME account private key secret, retrying", "error", err.Error())
+		}
+		return err
+	})
 	switch {
 	case !a.issuer.GetSpec().ACME.DisableAccountKeyGeneration && apierrors.IsNotFound(err):
 		log.V(logf.InfoLevel).Info("generating acme account private key")
-		pk, err = a.createAccountPrivateKey(ctx, privateKeySelector, ns)
+		accountPrivKey, err := a.createAccountPrivateKey(ctx, privateKeySelector, ns)
 		if err != nil {
 			msg = messageAccountRegistrationFailed + err.Error()
 			reason = errorAccountRegistrationFailed
 			return fmt.Errorf(msg)
 		}
+		pk = accountPrivKey
 		// We clear the ACME account URI as we have generated a new private key
 		a.issuer.GetStatus().ACMEStatus().URI = ""
+		a.issuer.GetStatus().ACMEStatus().AccountKeyParameters = accountKeyParameters(accountPrivKey)
 
 	case a.issuer.GetSpec().ACME.DisableAccountKeyGeneration && apierrors.IsNotFound(err):
 		wrapErr := fmt.Errorf("%s%s%v", messageAccountVerificationFailed,
@@ -129,6 +188,11 @@ func (a *Acme) Setup(ctx context.Context) error {
 		// fixed by https://github.com/cert-manager/cert-manager/issues/4004
 		return wrapErr
 
+	case errors.IsInvalidSecretType(err):
+		reason = errorAccountKeySecretType
+		msg = fmt.Sprintf("%s%v", messageAccountKeySecretType, err)
+		return nil
+
 	case errors.IsInvalidData(err):
 		reason = errorAccountVerificationFailed
 		msg = fmt.Sprintf("%s%v", messageInvalidPrivateKey, err)
@@ -157,8 +221,59 @@ func (a *Acme) Setup(ctx context.Context) error {
 	a.accountRegistry.RemoveClient(string(a.issuer.GetUID()))
 
 	httpClient := accounts.BuildHTTPClientWithCABundle(a.metrics, a.issuer.GetSpec().ACME.SkipTLSVerify, a.issuer.GetSpec().ACME.CABundle)
+	if a.httpTimeout > 0 {
+		httpClient.Timeout = a.httpTimeout
+	}
+	if lang := a.issuer.GetSpec().ACME.PreferredLanguage; len(lang) > 0 {
+		// Request localized problem documents from the ACME server.
+		httpClient.Transport = client.NewAcceptLanguageTransport(httpClient.Transport, lang)
+	}
+
+	// Record any deprecation warnings returned by the ACME server during
+	// Setup and surface them as events once Setup has finished. Only the
+	// client used in Setup records warnings, the client stored in the account
+	// registry uses the unwrapped HTTP client.
+	setupHTTPClient := *httpClient
+	warningTransport := client.NewWarningTransport(httpClient.Transport)
+	setupHTTPClient.Transport = warningTransport
+	defer func() {
+		for _, warning := range warningTransport.Warnings() {
+			log.Info("ACME server returned a deprecation warning", "warning", warning)
+			a.recordEvent(corev1.EventTypeWarning, warningDeprecation, fmt.Sprintf(messageTemplateDeprecationWarning, warning))
+		}
+	}()
+
+	// Compare the time reported by the ACME server against the local clock,
+	// as significant clock skew can cause requests to fail in ways that are
+	// hard to diagnose.
+	if a.clockSkewThreshold > 0 {
+		clockSkewTransport := client.NewClockSkewTransport(setupHTTPClient.Transport, a.clock)
+		setupHTTPClient.Transport = clockSkewTransport
+		defer func() {
+			skew, ok := clockSkewTransport.Skew()
+			if !ok {
+				return
+			}
+			if skew < 0 {
+				skew = -skew
+			}
+			if skew > a.clockSkewThreshold {
+				log.Info("detected clock skew between the local clock and the ACME server", "skew", skew, "threshold", a.clockSkewThreshold)
+				a.recordEvent(corev1.EventTypeWarning, warningClockSkew, fmt.Sprintf(messageTemplateClockSkew, skew, a.clockSkewThreshold))
+			}
+		}()
+	}
+
+	// Send a unique correlation ID on every request made during Setup, so
+	// that this reconcile can be correlated with the logs of the ACME server.
+	if a.correlationIDHeader != "" {
+		correlationID := string(uuid.NewUUID())
+		log = log.WithValues("correlationID", correlationID)
+		log.V(logf.InfoLevel).Info("sending correlation ID to the ACME server", "header", a.correlationIDHeader)
+		setupHTTPClient.Transport = client.NewCorrelationIDTransport(setupHTTPClient.Transport, a.correlationIDHeader, correlationID)
+	}
 
-	cl := a.clientBuilder(httpClient, *a.issuer.GetSpec().ACME, rsaPk, a.userAgent)
+	cl := a.clientBuilder(&setupHTTPClient, *a.issuer.GetSpec().ACME, rsaPk, a.userAgent)
 
 	// TODO: perform a complex check to determine whether we need to verify
 	// the existing registration with the ACME server.
@@ -173,7 +288,7 @@ func (a *Acme) Setup(ctx context.Context) error {
 	if err != nil {
 		reason = errorInvalidURL
 		msg = fmt.Sprintf(messageTemplateFailedToParseURL, rawServerURL, err)
-		a.recorder.Event(a.issuer, corev1.EventTypeWarning, errorInvalidURL, msg)
+		a.recordEvent(corev1.EventTypeWarning, errorInvalidURL, msg)
 		// absorb errors as retrying will not help resolve this error
 		return nil
 	}
@@ -183,7 +298,7 @@ func (a *Acme) Setup(ctx context.Context) error {
 	if err != nil {
 		reason = errorInvalidURL
 		msg = fmt.Sprintf(messageTemplateFailedToParseAccountURL, rawAccountURL, err)
-		a.recorder.Event(a.issuer, corev1.EventTypeWarning, errorInvalidURL, msg)
+		a.recordEvent(corev1.EventTypeWarning, errorInvalidURL, msg)
 		// absorb errors as retrying will not help resolve this error
 		return nil
 	}
@@ -232,7 +347,7 @@ func (a *Acme) Setup(ctx context.Context) error {
 			log.Error(err, "failed to verify ACME account")
 			reason = errorAccountRegistrationFailed
 			msg = messageAccountRegistrationFailed + err.Error()
-			a.recorder.Event(a.issuer, corev1.EventTypeWarning,
+			a.recordEvent(corev1.EventTypeWarning,
 				errorAccountRegistrationFailed,
 				msg)
 			return nil
@@ -250,8 +365,44 @@ func (a *Acme) Setup(ctx context.Context) error {
 		}
 	}
 
+	validateEAB := utilfeature.DefaultFeatureGate.Enabled(feature.ValidateACMEExternalAccountBinding)
+	if validateEAB {
+		if err := validateExternalAccountBinding(ctx, cl, eabAccount); err != nil {
+			log.Error(err, "failed to validate the External Account Binding")
+			reason = errorAccountEABRejected
+			msg = messageAccountEABRejected + err.Error()
+			a.recordEvent(corev1.EventTypeWarning, errorAccountEABRejected, msg)
+			// absorb errors as retrying will not help resolve this error
+			return nil
+		}
+	}
+
+	// Look up the terms of service currently published by the ACME server.
+	// If the issuer requires explicit agreement, the account is only
+	// registered or verified if these are the terms that were agreed to.
+	acceptedTermsURL := a.issuer.GetSpec().ACME.AcceptedTermsOfServiceURL
+	var termsURL string
+	dir, err := cl.Discover(ctx)
+	switch {
+	case err != nil && acceptedTermsURL != "":
+		reason = errorAccountRegistrationFailed
+		msg = messageAccountRegistrationFailed + err.Error()
+		return fmt.Errorf(msg)
+	case err != nil:
+		log.V(logf.DebugLevel).Info("skipping terms of service check as the ACME directory could not be fetched", "error", err.Error())
+	default:
+		termsURL = dir.Terms
+	}
+	if acceptedTermsURL != "" && termsURL != "" && termsURL != acceptedTermsURL {
+		reason = errorTermsOfServiceNotAccepted
+		msg = fmt.Sprintf(messageTemplateTermsOfServiceNotAccepted, termsURL, acceptedTermsURL)
+		a.recordEvent(corev1.EventTypeWarning, errorTermsOfServiceNotAccepted, msg)
+		// absorb errors as retrying will not help until the issuer is updated
+		return nil
+	}
+
 	// register an ACME account or retrieve it if it already exists.
-	account, err := a.registerAccount(ctx, cl, eabAccount)
+	account, created, err := a.registerAccount(ctx, cl, eabAccount)
 	if err != nil {
 		// TODO: this error could be from an account registration or an attempt
 		// to retrieve an existing account- perhaps we should log different
@@ -266,6 +417,11 @@ func (a *Acme) Setup(ctx context.Context) error {
 			return err
 		}
 
+		if validateEAB && isExternalAccountBindingError(acmeErr, eabAccount) {
+			reason = errorAccountEABRejected
+			msg = messageAccountEABRejected + err.Error()
+		}
+
 		// If the status code is 400 (BadRequest), we will *not* retry this registration
 		// as it implies that something about the request (i.e. email address or private key)
 		// is invalid.
@@ -287,7 +443,7 @@ func (a *Acme) Setup(ctx context.Context) error {
 		reason = errorAccountUpdateFailed
 		msg = messageAccountUpdateFailed + err.Error()
 		log.Error(err, "failed to update ACME account")
-		a.recorder.Event(a.issuer, corev1.EventTypeWarning, errorAccountUpdateFailed, msg)
+		a.recordEvent(corev1.EventTypeWarning, errorAccountUpdateFailed, msg)
 
 		acmeErr, ok := err.(*acmeapi.Error)
 		// If this is not an ACME error, we will simply return it and retry later
@@ -308,7 +464,44 @@ func (a *Acme) Setup(ctx context.Context) error {
 		return err
 	}
 
-	log.V(logf.InfoLevel).Info("verified existing registration with ACME server")
+	// Some ACME servers respond with 201 Created for accounts that already
+	// exist, so an account is only considered newly created if its URI, taken
+	// from the Location header of the response, differs from the one we have
+	// previously recorded.
+	if created && account.URI != "" && account.URI == a.issuer.GetStatus().ACMEStatus().URI {
+		created = false
+	}
+	if created {
+		log.V(logf.InfoLevel).Info("created new registration with ACME server")
+		registeredAt := metav1.NewTime(a.clock.Now())
+		a.issuer.GetStatus().ACMEStatus().RegisteredAt = &registeredAt
+		a.recordEvent(corev1.EventTypeNormal, successAccountRegistered, messageAccountCreated)
+	} else {
+		log.V(logf.InfoLevel).Info("verified existing registration with ACME server")
+		a.recordEvent(corev1.EventTypeNormal, successAccountExisting, messageAccountExisting)
+	}
+
+	// Record the terms of service the account was registered under, and warn
+	// if the ACME server has since published new terms that have not been
+	// explicitly agreed to.
+	acmeStatus := a.issuer.GetStatus().ACMEStatus()
+	switch {
+	case termsURL == "":
+	case created || acmeStatus.TermsOfServiceURL == "" || termsURL == acceptedTermsURL:
+		acmeStatus.TermsOfServiceURL = termsURL
+	case termsURL != acmeStatus.TermsOfServiceURL:
+		log.Info("ACME server has published new terms of service", "termsOfService", termsURL, "agreedTermsOfService", acmeStatus.TermsOfServiceURL)
+		a.recordEvent(corev1.EventTypeWarning, warningTermsOfServiceChanged, fmt.Sprintf(messageTemplateTermsOfServiceChanged, termsURL, acmeStatus.TermsOfServiceURL))
+	}
+
+	// Record the key the account was verified with, and when, so that the
+	// registration can be reported on from the status alone.
+	// Computing the thumbprint only fails for unsupported key types
+	thumbprint, _ := acmeapi.JWKThumbprint(rsaPk.Public())
+	verifiedAt := metav1.NewTime(a.clock.Now())
+	acmeStatus.AccountKeyThumbprint = thumbprint
+	acmeStatus.LastVerifiedAt = &verifiedAt
+
 	status = cmmeta.ConditionTrue
 	reason = successAccountRegistered
 	msg = messageAccountRegistered
@@ -320,42 +513,103 @@ func (a *Acme) Setup(ctx context.Context) error {
 	return nil
 }
 
+// recordEvent records an event for the issuer. If any of the configured event
+// annotation keys are set on the issuer, their values are attached to the event
+// as annotations. These are deliberately not used as metric labels, as their
+// values are unbounded.
+func (a *Acme) recordEvent(eventtype, reason, message string) {
+	annotations := make(map[string]string)
+	for _, key := range a.eventAnnotationKeys {
+		if value, ok := a.issuer.GetObjectMeta().Annotations[key]; ok {
+			annotations[key] = value
+		}
+	}
+
+	if len(annotations) == 0 {
+		a.recorder.Event(a.issuer, eventtype, reason, message)
+		return
+	}
+
+	a.recorder.AnnotatedEventf(a.issuer, annotations, eventtype, reason, "%s", message)
+}
+
+// isTransientSecretReadError returns true if the given error returned when
+// reading the account key secret is likely to resolve itself, such as an
+// apiserver timeout or throttling. NotFound and invalid data errors are not
+// transient.
+func isTransientSecretReadError(err error) bool {
+	return apierrors.IsTimeout(err) ||
+		apierrors.IsServerTimeout(err) ||
+		apierrors.IsTooManyRequests(err) ||
+		apierrors.IsInternalError(err) ||
+		apierrors.IsServiceUnavailable(err) ||
+		apierrors.IsUnexpectedServerError(err)
+}
+
 func ensureEmailUpToDate(ctx context.Context, cl client.Interface, acc *acmeapi.Account, specEmail string) (*acmeapi.Account, string, error) {
 	log := logf.FromContext(ctx)
 
-	// if no email was specified, then registeredEmail will remain empty
-	registeredEmail := ""
-	if len(acc.Contact) > 0 {
-		registeredEmail = strings.Replace(acc.Contact[0], "mailto:", "", 1)
+	// if no email was specified, the desired contacts will be empty
+	desiredContacts := []string(nil)
+	if specEmail != "" {
+		desiredContacts = []string{fmt.Sprintf("mailto:%s", strings.ToLower(specEmail))}
 	}
 
-	// if they are different, we update the account
-	if registeredEmail != specEmail {
+	// Only update the account if the contacts genuinely differ, so that
+	// differences in ordering, case or the presence of the mailto: scheme do
+	// not result in an UpdateReg call on every reconcile.
+	if !reflect.DeepEqual(normalizeContacts(acc.Contact), normalizeContacts(desiredContacts)) {
 		log.V(logf.DebugLevel).Info("updating ACME account email address", "email", specEmail)
-		emailurl := []string(nil)
-		if specEmail != "" {
-			emailurl = []string{fmt.Sprintf("mailto:%s", strings.ToLower(specEmail))}
-		}
-		acc.Contact = emailurl
+		acc.Contact = desiredContacts
 
 		var err error
 		acc, err = cl.UpdateReg(ctx, acc)
 		if err != nil {
 			return nil, "", err
 		}
+	}
+
+	return acc, specEmail, nil
+}
+
+// normalizeContacts returns a canonical form of the given ACME account
+// contacts, suitable for comparing two lists of contacts. Whitespace is
+// trimmed, mailto: contacts are lowercased, bare email addresses are given
+// the mailto: scheme, and the result is deduplicated and sorted. Empty
+// contacts are removed, and nil is returned if no contacts remain.
+func normalizeContacts(contacts []string) []string {
+	set := sets.NewString()
+	for _, contact := range contacts {
+		contact = strings.TrimSpace(contact)
+		if contact == "" {
+			continue
+		}
+
+		scheme, address, found := strings.Cut(contact, ":")
+		switch {
+		case !found && strings.Contains(contact, "@"):
+			contact = "mailto:" + strings.ToLower(contact)
+		case found && strings.EqualFold(scheme, "mailto"):
+			contact = "mailto:" + strings.ToLower(address)
+		}
+
+		set.Insert(contact)
+	}
 
-		// update the registeredEmail var so it is updated properly in the status below
-		registeredEmail = specEmail
+	if set.Len() == 0 {
+		return nil
 	}
 
-	return acc, registeredEmail, nil
+	return set.List()
 }
 
 // registerAccount will register a new ACME account with the server. If an
 // account with the clients private key already exists, it will attempt to look
 // up and verify the corresponding account, and will return that. If this fails
 // due to a not found error it will register a new account with the given key.
-func (a *Acme) registerAccount(ctx context.Context, cl client.Interface, eabAccount *acmeapi.ExternalAccountBinding) (*acmeapi.Account, error) {
+// The returned bool is true if the ACME server reported that the account was
+// newly created.
+func (a *Acme) registerAccount(ctx context.Context, cl client.Interface, eabAccount *acmeapi.ExternalAccountBinding) (*acmeapi.Account, bool, error) {
 	emailurl := []string(nil)
 	if a.issuer.GetSpec().ACME.Email != "" {
 		emailurl = []string{fmt.Sprintf("mailto:%s", strings.ToLower(a.issuer.GetSpec().ACME.Email))}
@@ -368,19 +622,76 @@ func (a *Acme) registerAccount(ctx context.Context, cl client.Interface, eabAcco
 
 	// private key, server URL and HTTP options are stored in the ACME client (cl).
 	acc, err := cl.Register(ctx, acc, acmeapi.AcceptTOS)
-	// If the account already exists, fetch the Account object and return.
+	// If the account already exists, which the ACME client signals for a 200 OK
+	// response, fetch the Account object and return.
 	if err == acmeapi.ErrAccountAlreadyExists {
-		return cl.GetReg(ctx, "")
+		acc, err := cl.GetReg(ctx, "")
+		return acc, false, err
 	}
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	// TODO: re-enable this check once this field is set by Pebble
 	// if acc.Status != acme.StatusValid {
 	// 	return nil, fmt.Errorf("acme account is not valid")
 	// }
 
-	return acc, nil
+	return acc, true, nil
+}
+
+// validateExternalAccountBinding performs a pre-flight check of the External
+// Account Binding configuration against the ACME server's directory.
+// RFC 8555 does not define a request that validates an External Account
+// Binding without registering an account, so this can only catch a mismatch
+// between the server's requirements and the issuer's configuration before the
+// account is registered. If the directory cannot be fetched the check is
+// skipped and registration proceeds as normal.
+func validateExternalAccountBinding(ctx context.Context, cl client.Interface, eab *acmeapi.ExternalAccountBinding) error {
+	log := logf.FromContext(ctx)
+
+	dir, err := cl.Discover(ctx)
+	if err != nil {
+		log.V(logf.DebugLevel).Info("skipping External Account Binding pre-flight check as the ACME directory could not be fetched", "error", err.Error())
+		return nil
+	}
+
+	if eab == nil {
+		if dir.ExternalAccountRequired {
+			return fmt.Errorf("the ACME server requires an External Account Binding, but none is configured on the issuer")
+		}
+		return nil
+	}
+
+	if len(eab.Key) == 0 {
+		return fmt.Errorf("the External Account Binding key for key ID %q is empty", eab.KID)
+	}
+
+	return nil
+}
+
+// isExternalAccountBindingError returns true if the given ACME error returned
+// during account registration was caused by the External Account Binding.
+// An error is only attributed to the External Account Binding if one was sent
+// and the problem type or detail refers to it, as ACME servers reject an
+// External Account Binding with generic problem types such as unauthorized.
+func isExternalAccountBindingError(acmeErr *acmeapi.Error, eab *acmeapi.ExternalAccountBinding) bool {
+	if eab == nil {
+		return false
+	}
+	if acmeErr.ProblemType == "urn:ietf:params:acme:error:externalAccountRequired" {
+		return true
+	}
+
+	detail := strings.ToLower(acmeErr.Detail)
+	if strings.Contains(detail, "external account") {
+		return true
+	}
+	for _, word := range strings.FieldsFunc(detail, func(r rune) bool { return !unicode.IsLetter(r) }) {
+		if word == "eab" {
+			return true
+		}
+	}
+	return false
 }
 
 func (a *Acme) getEABKey(ctx context.Context, ns string) ([]byte, error) {
@@ -421,13 +732,17 @@ func (a *Acme) createAccountPrivateKey(ctx context.Context, sel cmmeta.SecretKey
 		return nil, err
 	}
 
+	// The encoded key is only needed until the Secret has been created.
+	keyPEM := pki.EncodePKCS1PrivateKey(accountPrivKey)
+	defer pki.Zeroize(keyPEM)
+
 	_, err = a.secretsClient.Secrets(ns).Create(ctx, &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      sel.Name,
 			Namespace: ns,
 		},
 		Data: map[string][]byte{
-			sel.Key: pki.EncodePKCS1PrivateKey(accountPrivKey),
+			sel.Key: keyPEM,
 		},
 	}, metav1.CreateOptions{})
 
@@ -438,6 +753,17 @@ func (a *Acme) createAccountPrivateKey(ctx context.Context, sel cmmeta.SecretKey
 	return accountPrivKey, err
 }
 
+// accountKeyParameters returns the parameters that were used by
+// createAccountPrivateKey to generate and store the given account key.
+func accountKeyParameters(pk *rsa.PrivateKey) *cmacme.ACMEAccountKeyParameters {
+	return &cmacme.ACMEAccountKeyParameters{
+		Algorithm:      string(v1.RSAKeyAlgorithm),
+		Size:           pk.N.BitLen(),
+		Encoding:       string(v1.PKCS1),
+		PublicExponent: pk.E,
+	}
+}
+
 var (
 	acmev1Staging = "https://acme-staging.api.letsencrypt.org/directory"
 	acmev1Prod    = "https://acme-v01.api.letsencrypt.org/directory"
@@ -448,7 +774,4 @@ var (
 var acmev1ToV2Mappings = map[string]string{
 	acmev1Prod:    acmev2Prod,
 	acmev1Staging: acmev2Staging,
-	// trailing slashes for v1 URLs
-	fmt.Sprintf("%s/", acmev1Prod):    acmev2Prod,
-	fmt.Sprintf("%s/", acmev1Staging): acmev2Staging,
 }
@@ -22,6 +22,7 @@ import (
 	"crypto/rsa"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"reflect"
 	"testing"
@@ -31,9 +32,11 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
 	fakeclock "k8s.io/utils/clock/testing"
 
-	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	fakeregistry "github.com/cert-manager/cert-manager/pkg/acme/accounts/test"
 	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
@@ -41,8 +44,11 @@ import (
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	controllertest "github.com/cert-manager/cert-manager/pkg/controller/test"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 	"github.com/cert-manager/cert-manager/pkg/util"
 	"github.com/cert-manager/cert-manager/pkg/util/errors"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	"github.com/cert-manager/cert-manager/test/unit/coreclients"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
@@ -67,15 +73,27 @@ func TestAcme_Setup(t *testing.T) {
 			gen.SetIssuerConditionLastTransitionTime(&nowMetaTime))
 		issuerSecretKeyName = "test"
 
+		accountURI           = "https://acme-v02.api.letsencrypt.org/acme/acct/1"
+		accountCreatedEvent  = fmt.Sprintf("%s %s %s", corev1.EventTypeNormal, successAccountRegistered, messageAccountCreated)
+		accountExistingEvent = fmt.Sprintf("%s %s %s", corev1.EventTypeNormal, successAccountExisting, messageAccountExisting)
+
+		termsURL    = "https://example.com/terms/2024.pdf"
+		oldTermsURL = "https://example.com/terms/2023.pdf"
+
 		ecdsaPrivKey = mustGenerateEDCSAKey(t)
 		rsaPrivKey   = mustGenerateRSAKey(t)
 
-		notFoundErr    = apierrors.NewNotFound(corev1.Resource("test"), "test")
-		invalidDataErr = errors.NewInvalidData("test")
-		someErr        = fmt.Errorf("test")
-		invalidURL     = "%"
-		acmeErr450     = &acmeapi.Error{StatusCode: 450}
-		acmeErr500     = &acmeapi.Error{StatusCode: 500}
+		notFoundErr      = apierrors.NewNotFound(corev1.Resource("test"), "test")
+		invalidDataErr   = errors.NewInvalidData("test")
+		secretTypeErr    = errors.NewInvalidSecretType("test")
+		someErr          = fmt.Errorf("test")
+		invalidURL       = "%"
+		acmeErr450       = &acmeapi.Error{StatusCode: 450}
+		acmeErr500       = &acmeapi.Error{StatusCode: 500}
+		acmeErrUnauth    = &acmeapi.Error{StatusCode: 403, ProblemType: "urn:ietf:params:acme:error:unauthorized"}
+		acmeErrEABUnauth = &acmeapi.Error{StatusCode: 403, ProblemType: "urn:ietf:params:acme:error:unauthorized",
+			Detail: "the external account binding is invalid"}
+		transientErr = apierrors.NewServiceUnavailable("test")
 		//TODO: we should probably mock calls to net/url instead of doing this.
 		invalidURLErr = parseURLErr(invalidURL)
 
@@ -103,10 +121,24 @@ func TestAcme_Setup(t *testing.T) {
 	tests := map[string]struct {
 		issuer cmapi.GenericIssuer
 
+		// Prefix prepended to the reason of the issuer's Ready condition.
+		conditionReasonPrefix string
+		bootstrapDeadline     time.Time
+
+		// Whether the ValidateACMEExternalAccountBinding feature gate is enabled.
+		validateEABFeatureEnabled bool
+		// ACME directory returned by cl.Discover
+		discoverDir acmeapi.Directory
+		// Error returned by cl.Discover
+		discoverErr error
+
 		// Private key returned by keyFromSecret stub.
 		kfsKey crypto.Signer
 		// Error returned by keyFromSecret stub.
 		kfsErr error
+		// Number of transient errors returned by keyFromSecret stub before
+		// kfsKey and kfsErr are returned.
+		kfsTransientErrs int
 
 		// Whether RemoveClient should be called.
 		removeClientShouldBeCalled bool
@@ -122,6 +154,10 @@ func TestAcme_Setup(t *testing.T) {
 		// Error returned by cl.GetReg
 		getRegErr error
 
+		// URI of the account returned by cl.Register, taken from the Location
+		// header of the ACME server's response.
+		registerAccURI string
+
 		// Error return by cl.UpdateRegistration
 		updateRegError error
 
@@ -137,8 +173,17 @@ func TestAcme_Setup(t *testing.T) {
 		expectedRegisteredAcc *acmeapi.Account
 		// expected issuer conditions after Setup has been called.
 		expectedConditions []cmapi.IssuerCondition
-		expectedEvents     []string
-		wantsErr           bool
+		// expected account key parameters in the issuer's ACME status after
+		// Setup has been called.
+		expectedAccountKeyParameters *cmacme.ACMEAccountKeyParameters
+		// expected registration time in the issuer's ACME status after Setup
+		// has been called.
+		expectedRegisteredAt *metav1.Time
+		// expected terms of service URL in the issuer's ACME status after
+		// Setup has been called.
+		expectedTermsOfServiceURL string
+		expectedEvents            []string
+		wantsErr                  bool
 	}{
 		"LetsEncrypt ACME v1 prod URL specified, return early": {
 			issuer: gen.IssuerFrom(baseIssuer,
@@ -149,13 +194,14 @@ func TestAcme_Setup(t *testing.T) {
 					gen.SetIssuerConditionMessage(fmt.Sprintf(messageTemplateUpdateToV2, acmev1Prod, acmev2Prod))),
 			},
 		},
-		"LetsEncrypt ACME v1 staging URL with trailing slash specified, return early": {
+		"Condition reason prefix is configured, prefix is prepended to the Ready condition reason": {
 			issuer: gen.IssuerFrom(baseIssuer,
-				gen.SetIssuerACMEURL(fmt.Sprintf("%s/", acmev1Staging))),
+				gen.SetIssuerACMEURL(acmev1Prod)),
+			conditionReasonPrefix: "TenantA",
 			expectedConditions: []cmapi.IssuerCondition{
 				*gen.IssuerConditionFrom(readyFalseCondition,
-					gen.SetIssuerConditionReason(errorInvalidConfig),
-					gen.SetIssuerConditionMessage(fmt.Sprintf(messageTemplateUpdateToV2, fmt.Sprintf("%s/", acmev1Staging), acmev2Staging))),
+					gen.SetIssuerConditionReason("TenantA"+errorInvalidConfig),
+					gen.SetIssuerConditionMessage(fmt.Sprintf(messageTemplateUpdateToV2, acmev1Prod, acmev2Prod))),
 			},
 		},
 		"ACME private key secret does not exist, account key generation not disabled, key secret creation fails": {
@@ -182,15 +228,47 @@ func TestAcme_Setup(t *testing.T) {
 			},
 			wantsErr: true,
 		},
+		"email is required but not set": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerACMERequireEmail(true),
+			),
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyFalseCondition,
+					gen.SetIssuerConditionReason(errorEmailRequired),
+					gen.SetIssuerConditionMessage(messageEmailRequired)),
+			},
+		},
+		"email is required and set, account is registered with the email": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerACMERequireEmail(true),
+				gen.SetIssuerACMEEmail(someEmail),
+			),
+			kfsKey:                     rsaPrivKey,
+			removeClientShouldBeCalled: true,
+			addClientShouldBeCalled:    true,
+			expectedRegisteredAcc:      &acmeapi.Account{Contact: []string{someEmailURL}},
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyTrueCondition)},
+			expectedRegisteredAt: &nowMetaTime,
+			expectedEvents:       []string{accountCreatedEvent},
+		},
 		"ACME private key secret does not exist, account key generation is enabled, key creation succeeds": {
 			issuer:      gen.IssuerFrom(baseIssuer),
 			kfsErr:      notFoundErr,
 			acmePrivKey: rsaPrivKey.(*rsa.PrivateKey),
 			expectedConditions: []cmapi.IssuerCondition{
 				*gen.IssuerConditionFrom(readyTrueCondition)},
+			expectedAccountKeyParameters: &cmacme.ACMEAccountKeyParameters{
+				Algorithm:      "RSA",
+				Size:           pki.MinRSAKeySize,
+				Encoding:       "PKCS1",
+				PublicExponent: 65537,
+			},
 			removeClientShouldBeCalled: true,
 			addClientShouldBeCalled:    true,
 			expectedRegisteredAcc:      &acmeapi.Account{},
+			expectedRegisteredAt:       &nowMetaTime,
+			expectedEvents:             []string{accountCreatedEvent},
 		},
 		"ACME private key secret exists, but contains invalid private key": {
 			issuer: gen.IssuerFrom(baseIssuer),
@@ -201,6 +279,15 @@ func TestAcme_Setup(t *testing.T) {
 					gen.SetIssuerConditionMessage(fmt.Sprintf("%s%v", messageInvalidPrivateKey, invalidDataErr))),
 			},
 		},
+		"ACME private key secret exists, but is of an incompatible type": {
+			issuer: gen.IssuerFrom(baseIssuer),
+			kfsErr: secretTypeErr,
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyFalseCondition,
+					gen.SetIssuerConditionReason(errorAccountKeySecretType),
+					gen.SetIssuerConditionMessage(fmt.Sprintf("%s%v", messageAccountKeySecretType, secretTypeErr))),
+			},
+		},
 		"Checking ACME private key secret fails with an unknown error": {
 			issuer: gen.IssuerFrom(baseIssuer),
 			kfsErr: someErr,
@@ -211,6 +298,62 @@ func TestAcme_Setup(t *testing.T) {
 			},
 			wantsErr: true,
 		},
+		"Checking ACME private key secret fails with an unknown error during the bootstrap grace period": {
+			issuer:            gen.IssuerFrom(baseIssuer),
+			kfsErr:            someErr,
+			bootstrapDeadline: fixedClockStart.Add(time.Minute),
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyFalseCondition,
+					gen.SetIssuerConditionStatus(cmmeta.ConditionUnknown),
+					gen.SetIssuerConditionReason(reasonBootstrapping),
+					gen.SetIssuerConditionMessage(messageBootstrapping+messageAccountVerificationFailed+someErr.Error())),
+			},
+			wantsErr: true,
+		},
+		"Checking ACME private key secret fails with an unknown error after the bootstrap grace period": {
+			issuer:            gen.IssuerFrom(baseIssuer),
+			kfsErr:            someErr,
+			bootstrapDeadline: fixedClockStart.Add(-time.Minute),
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyFalseCondition,
+					gen.SetIssuerConditionReason(errorAccountVerificationFailed),
+					gen.SetIssuerConditionMessage(messageAccountVerificationFailed+someErr.Error())),
+			},
+			wantsErr: true,
+		},
+		"ACME private key is invalid during the bootstrap grace period, the error is not transient": {
+			issuer:            gen.IssuerFrom(baseIssuer),
+			kfsErr:            invalidDataErr,
+			bootstrapDeadline: fixedClockStart.Add(time.Minute),
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyFalseCondition,
+					gen.SetIssuerConditionReason(errorAccountVerificationFailed),
+					gen.SetIssuerConditionMessage(fmt.Sprintf("%s%v", messageInvalidPrivateKey, invalidDataErr))),
+			},
+		},
+		"Reading ACME private key secret fails with transient errors, then succeeds": {
+			issuer:                     gen.IssuerFrom(baseIssuer),
+			kfsKey:                     rsaPrivKey,
+			kfsTransientErrs:           2,
+			removeClientShouldBeCalled: true,
+			addClientShouldBeCalled:    true,
+			expectedRegisteredAcc:      &acmeapi.Account{},
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyTrueCondition)},
+			expectedRegisteredAt: &nowMetaTime,
+			expectedEvents:       []string{accountCreatedEvent},
+		},
+		"Reading ACME private key secret keeps failing with transient errors": {
+			issuer:           gen.IssuerFrom(baseIssuer),
+			kfsKey:           rsaPrivKey,
+			kfsTransientErrs: 10,
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyFalseCondition,
+					gen.SetIssuerConditionReason(errorAccountVerificationFailed),
+					gen.SetIssuerConditionMessage(messageAccountVerificationFailed+transientErr.Error())),
+			},
+			wantsErr: true,
+		},
 		"ACME account's key is not an RSA key": {
 			issuer: gen.IssuerFrom(baseIssuer,
 				gen.SetIssuerACMEPrivKeyRef(issuerSecretKeyName)),
@@ -266,6 +409,43 @@ func TestAcme_Setup(t *testing.T) {
 			removeClientShouldBeCalled: true,
 			addClientShouldBeCalled:    true,
 		},
+		"ACME Issuer is ready, server URL only differs from the account URL by a trailing slash": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerACMEURL(fmt.Sprintf("%s/", acmev2Prod)),
+				gen.SetIssuerACMEAccountURL(acmev2Prod),
+				gen.SetIssuerACMEEmail(someEmail),
+				gen.SetIssuerACMELastRegisteredEmail(someEmail),
+				gen.AddIssuerCondition(
+					*gen.IssuerConditionFrom(readyTrueCondition,
+						gen.SetIssuerConditionStatus(cmmeta.ConditionTrue)))),
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyTrueCondition,
+					gen.SetIssuerConditionStatus(cmmeta.ConditionTrue),
+					gen.SetIssuerConditionMessage(messageAccountRegistered),
+					gen.SetIssuerConditionReason(successAccountRegistered)),
+			},
+			kfsKey:                     rsaPrivKey,
+			removeClientShouldBeCalled: true,
+			addClientShouldBeCalled:    true,
+		},
+		"ACME Issuer is ready, account URL only differs from the server URL by a trailing slash": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerACMEAccountURL(fmt.Sprintf("%s/", acmev2Prod)),
+				gen.SetIssuerACMEEmail(someEmail),
+				gen.SetIssuerACMELastRegisteredEmail(someEmail),
+				gen.AddIssuerCondition(
+					*gen.IssuerConditionFrom(readyTrueCondition,
+						gen.SetIssuerConditionStatus(cmmeta.ConditionTrue)))),
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyTrueCondition,
+					gen.SetIssuerConditionStatus(cmmeta.ConditionTrue),
+					gen.SetIssuerConditionMessage(messageAccountRegistered),
+					gen.SetIssuerConditionReason(successAccountRegistered)),
+			},
+			kfsKey:                     rsaPrivKey,
+			removeClientShouldBeCalled: true,
+			addClientShouldBeCalled:    true,
+		},
 		"EAB for issuer specified, but the corresponding secret is not found": {
 			issuer: gen.IssuerFrom(baseIssuer,
 				gen.SetIssuerACMEEAB(someString, someString)),
@@ -363,6 +543,110 @@ func TestAcme_Setup(t *testing.T) {
 					gen.SetIssuerConditionReason(successAccountRegistered),
 					gen.SetIssuerConditionMessage(messageAccountRegistered)),
 			},
+			expectedRegisteredAt: &nowMetaTime,
+			expectedEvents:       []string{accountCreatedEvent},
+		},
+		"EAB validation enabled, ACME server requires EAB but none is configured": {
+			issuer:                     gen.IssuerFrom(baseIssuer),
+			kfsKey:                     rsaPrivKey,
+			removeClientShouldBeCalled: true,
+			validateEABFeatureEnabled:  true,
+			discoverDir:                acmeapi.Directory{ExternalAccountRequired: true},
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyFalseCondition,
+					gen.SetIssuerConditionReason(errorAccountEABRejected),
+					gen.SetIssuerConditionMessage(messageAccountEABRejected+"the ACME server requires an External Account Binding, but none is configured on the issuer")),
+			},
+			expectedEvents: []string{
+				fmt.Sprintf("%s %s %s", corev1.EventTypeWarning, errorAccountEABRejected, messageAccountEABRejected+"the ACME server requires an External Account Binding, but none is configured on the issuer"),
+			},
+		},
+		"EAB validation enabled, ACME directory cannot be fetched, fall back to registration": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerACMEEAB(someString, someString)),
+			kfsKey:                     rsaPrivKey,
+			removeClientShouldBeCalled: true,
+			addClientShouldBeCalled:    true,
+			validateEABFeatureEnabled:  true,
+			discoverErr:                someErr,
+			eabSecret:                  eabSecret,
+			expectedRegisteredAcc: &acmeapi.Account{ExternalAccountBinding: &acmeapi.ExternalAccountBinding{
+				KID: someString,
+				Key: []byte(eabKey),
+			}},
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyTrueCondition),
+			},
+			expectedRegisteredAt: &nowMetaTime,
+			expectedEvents:       []string{accountCreatedEvent},
+		},
+		"EAB validation enabled, ACME server rejects the EAB on registration": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerACMEEAB(someString, someString)),
+			kfsKey:                     rsaPrivKey,
+			removeClientShouldBeCalled: true,
+			validateEABFeatureEnabled:  true,
+			discoverDir:                acmeapi.Directory{ExternalAccountRequired: true},
+			eabSecret:                  eabSecret,
+			registerErr:                acmeErrEABUnauth,
+			expectedRegisteredAcc: &acmeapi.Account{ExternalAccountBinding: &acmeapi.ExternalAccountBinding{
+				KID: someString,
+				Key: []byte(eabKey),
+			}},
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyFalseCondition,
+					gen.SetIssuerConditionReason(errorAccountEABRejected),
+					gen.SetIssuerConditionMessage(messageAccountEABRejected+acmeErrEABUnauth.Error())),
+			},
+		},
+		"EAB validation enabled, ACME server rejects the registration for a reason unrelated to the EAB": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerACMEEAB(someString, someString)),
+			kfsKey:                     rsaPrivKey,
+			removeClientShouldBeCalled: true,
+			validateEABFeatureEnabled:  true,
+			discoverDir:                acmeapi.Directory{ExternalAccountRequired: true},
+			eabSecret:                  eabSecret,
+			registerErr:                acmeErrUnauth,
+			expectedRegisteredAcc: &acmeapi.Account{ExternalAccountBinding: &acmeapi.ExternalAccountBinding{
+				KID: someString,
+				Key: []byte(eabKey),
+			}},
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyFalseCondition,
+					gen.SetIssuerConditionReason(errorAccountRegistrationFailed),
+					gen.SetIssuerConditionMessage(messageAccountRegistrationFailed+acmeErrUnauth.Error())),
+			},
+		},
+		"EAB validation enabled, no EAB configured, ACME server rejects the registration as unauthorized": {
+			issuer:                     gen.IssuerFrom(baseIssuer),
+			kfsKey:                     rsaPrivKey,
+			removeClientShouldBeCalled: true,
+			validateEABFeatureEnabled:  true,
+			registerErr:                acmeErrEABUnauth,
+			expectedRegisteredAcc:      &acmeapi.Account{},
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyFalseCondition,
+					gen.SetIssuerConditionReason(errorAccountRegistrationFailed),
+					gen.SetIssuerConditionMessage(messageAccountRegistrationFailed+acmeErrEABUnauth.Error())),
+			},
+		},
+		"EAB validation disabled, ACME server rejects the EAB on registration": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerACMEEAB(someString, someString)),
+			kfsKey:                     rsaPrivKey,
+			removeClientShouldBeCalled: true,
+			eabSecret:                  eabSecret,
+			registerErr:                acmeErrUnauth,
+			expectedRegisteredAcc: &acmeapi.Account{ExternalAccountBinding: &acmeapi.ExternalAccountBinding{
+				KID: someString,
+				Key: []byte(eabKey),
+			}},
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyFalseCondition,
+					gen.SetIssuerConditionReason(errorAccountRegistrationFailed),
+					gen.SetIssuerConditionMessage(messageAccountRegistrationFailed+acmeErrUnauth.Error())),
+			},
 		},
 		"ACME account with legacy EAB key algorithm set and with an email is registered successfully": {
 			issuer: gen.IssuerFrom(baseIssuer,
@@ -384,6 +668,8 @@ func TestAcme_Setup(t *testing.T) {
 					gen.SetIssuerConditionReason(successAccountRegistered),
 					gen.SetIssuerConditionMessage(messageAccountRegistered)),
 			},
+			expectedRegisteredAt: &nowMetaTime,
+			expectedEvents:       []string{accountCreatedEvent},
 		},
 		"ACME account with legacy EAB key algorithm set, spec email different from registered email and registered successfully": {
 			issuer: gen.IssuerFrom(baseIssuer,
@@ -412,6 +698,7 @@ func TestAcme_Setup(t *testing.T) {
 					gen.SetIssuerConditionReason(successAccountRegistered),
 					gen.SetIssuerConditionMessage(messageAccountRegistered)),
 			},
+			expectedEvents: []string{accountExistingEvent},
 		},
 		"ACME account with legacy EAB key algorithm set, spec email different from registered email and registered failed": {
 			issuer: gen.IssuerFrom(baseIssuer,
@@ -506,9 +793,134 @@ func TestAcme_Setup(t *testing.T) {
 			expectedEvents: []string{
 				fmt.Sprintf("%s %s %s", corev1.EventTypeWarning, errorAccountUpdateFailed, fmt.Sprintf("%s%s", messageAccountUpdateFailed, acmeErr500.Error()))},
 		},
+		"ACME server responds with 201 Created for a new account, the registration time is recorded": {
+			issuer:                     gen.IssuerFrom(baseIssuer),
+			kfsKey:                     rsaPrivKey,
+			removeClientShouldBeCalled: true,
+			addClientShouldBeCalled:    true,
+			registerAccURI:             accountURI,
+			expectedRegisteredAcc:      &acmeapi.Account{},
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyTrueCondition)},
+			expectedRegisteredAt: &nowMetaTime,
+			expectedEvents:       []string{accountCreatedEvent},
+		},
+		"ACME server URL with a trailing slash is passed to the ACME client unchanged": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerACMEURL(fmt.Sprintf("%s/", acmev2Prod))),
+			kfsKey:                     rsaPrivKey,
+			removeClientShouldBeCalled: true,
+			addClientShouldBeCalled:    true,
+			registerAccURI:             accountURI,
+			expectedRegisteredAcc:      &acmeapi.Account{},
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyTrueCondition)},
+			expectedRegisteredAt: &nowMetaTime,
+			expectedEvents:       []string{accountCreatedEvent},
+		},
+		"ACME server responds with 200 OK for an existing account, the registration time is not recorded": {
+			issuer:                     gen.IssuerFrom(baseIssuer),
+			kfsKey:                     rsaPrivKey,
+			removeClientShouldBeCalled: true,
+			addClientShouldBeCalled:    true,
+			registerErr:                acmeapi.ErrAccountAlreadyExists,
+			getRegAcc:                  &acmeapi.Account{URI: accountURI},
+			expectedRegisteredAcc:      &acmeapi.Account{},
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyTrueCondition)},
+			expectedEvents: []string{accountExistingEvent},
+		},
+		"ACME server responds with 201 Created for the account already recorded on the issuer, the account is treated as existing": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerACMEAccountURL(accountURI)),
+			kfsKey:                     rsaPrivKey,
+			removeClientShouldBeCalled: true,
+			addClientShouldBeCalled:    true,
+			registerAccURI:             accountURI,
+			expectedRegisteredAcc:      &acmeapi.Account{},
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyTrueCondition)},
+			expectedEvents: []string{accountExistingEvent},
+		},
+		"ACME server publishes terms of service, the terms are recorded when the account is created": {
+			issuer:                     gen.IssuerFrom(baseIssuer),
+			kfsKey:                     rsaPrivKey,
+			removeClientShouldBeCalled: true,
+			addClientShouldBeCalled:    true,
+			discoverDir:                acmeapi.Directory{Terms: termsURL},
+			expectedRegisteredAcc:      &acmeapi.Account{},
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyTrueCondition)},
+			expectedRegisteredAt:      &nowMetaTime,
+			expectedTermsOfServiceURL: termsURL,
+			expectedEvents:            []string{accountCreatedEvent},
+		},
+		"ACME server publishes new terms of service for an existing account, a warning event is recorded": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerACMETermsOfServiceURL(oldTermsURL)),
+			kfsKey:                     rsaPrivKey,
+			removeClientShouldBeCalled: true,
+			addClientShouldBeCalled:    true,
+			discoverDir:                acmeapi.Directory{Terms: termsURL},
+			registerErr:                acmeapi.ErrAccountAlreadyExists,
+			getRegAcc:                  &acmeapi.Account{URI: accountURI},
+			expectedRegisteredAcc:      &acmeapi.Account{},
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyTrueCondition)},
+			expectedTermsOfServiceURL: oldTermsURL,
+			expectedEvents: []string{
+				accountExistingEvent,
+				fmt.Sprintf("%s %s %s", corev1.EventTypeWarning, warningTermsOfServiceChanged, fmt.Sprintf(messageTemplateTermsOfServiceChanged, termsURL, oldTermsURL)),
+			},
+		},
+		"New terms of service are explicitly accepted for an existing account, the accepted terms are recorded": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerACMEAcceptedTermsOfServiceURL(termsURL),
+				gen.SetIssuerACMETermsOfServiceURL(oldTermsURL)),
+			kfsKey:                     rsaPrivKey,
+			removeClientShouldBeCalled: true,
+			addClientShouldBeCalled:    true,
+			discoverDir:                acmeapi.Directory{Terms: termsURL},
+			registerErr:                acmeapi.ErrAccountAlreadyExists,
+			getRegAcc:                  &acmeapi.Account{URI: accountURI},
+			expectedRegisteredAcc:      &acmeapi.Account{},
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyTrueCondition)},
+			expectedTermsOfServiceURL: termsURL,
+			expectedEvents:            []string{accountExistingEvent},
+		},
+		"Accepted terms of service differ from those published by the ACME server, the account is not registered": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerACMEAcceptedTermsOfServiceURL(oldTermsURL)),
+			kfsKey:                     rsaPrivKey,
+			removeClientShouldBeCalled: true,
+			discoverDir:                acmeapi.Directory{Terms: termsURL},
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyFalseCondition,
+					gen.SetIssuerConditionReason(errorTermsOfServiceNotAccepted),
+					gen.SetIssuerConditionMessage(fmt.Sprintf(messageTemplateTermsOfServiceNotAccepted, termsURL, oldTermsURL))),
+			},
+			expectedEvents: []string{
+				fmt.Sprintf("%s %s %s", corev1.EventTypeWarning, errorTermsOfServiceNotAccepted, fmt.Sprintf(messageTemplateTermsOfServiceNotAccepted, termsURL, oldTermsURL)),
+			},
+		},
+		"Terms of service are required to be accepted but the ACME directory cannot be fetched, retry": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerACMEAcceptedTermsOfServiceURL(termsURL)),
+			kfsKey:                     rsaPrivKey,
+			removeClientShouldBeCalled: true,
+			discoverErr:                someErr,
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyFalseCondition,
+					gen.SetIssuerConditionReason(errorAccountRegistrationFailed),
+					gen.SetIssuerConditionMessage(messageAccountRegistrationFailed+someErr.Error())),
+			},
+			wantsErr: true,
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
+			defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, feature.ValidateACMEExternalAccountBinding, test.validateEABFeatureEnabled)()
 
 			// Secrets client that will be called from the Setup function to
 			// create new secrets and get EAB secret.
@@ -526,17 +938,27 @@ func TestAcme_Setup(t *testing.T) {
 
 			// Set up a mock keyFromSecret.
 			kfsWasCalled := false
-			kfs := keyFromSecretMockBuilder(&(kfsWasCalled), test.kfsKey, test.kfsErr)
+			kfsMock := keyFromSecretMockBuilder(&(kfsWasCalled), test.kfsKey, test.kfsErr)
+			kfsCalls := 0
+			kfs := func(ctx context.Context, namespace, name, keyName string) (crypto.Signer, error) {
+				kfsCalls++
+				if kfsCalls <= test.kfsTransientErrs {
+					return nil, transientErr
+				}
+				return kfsMock(ctx, namespace, name, keyName)
+			}
 
 			// Mock ACME accounts registry.
 			removeClientWasCalled := false
 			addClientWasCalled := false
+			addClientServerURL := ""
 			ar := &fakeregistry.FakeRegistry{
 				RemoveClientFunc: func(string) {
 					removeClientWasCalled = true
 				},
-				AddClientFunc: func(string, cmacme.ACMEIssuer, *rsa.PrivateKey, string) {
+				AddClientFunc: func(_ string, config cmacme.ACMEIssuer, _ *rsa.PrivateKey, _ string) {
 					addClientWasCalled = true
+					addClientServerURL = config.Server
 				},
 			}
 
@@ -545,7 +967,9 @@ func TestAcme_Setup(t *testing.T) {
 			cl := acmecl.FakeACME{
 				FakeRegister: func(_ context.Context, a *acmeapi.Account, _ func(string) bool) (*acmeapi.Account, error) {
 					gotAcc = a
-					return a, test.registerErr
+					acc := *a
+					acc.URI = test.registerAccURI
+					return &acc, test.registerErr
 				},
 				FakeGetReg: func(context.Context, string) (*acmeapi.Account, error) {
 					return test.getRegAcc, test.getRegErr
@@ -553,8 +977,15 @@ func TestAcme_Setup(t *testing.T) {
 				FakeUpdateReg: func(ctx context.Context, a *acmeapi.Account) (*acmeapi.Account, error) {
 					return a, test.updateRegError
 				},
+				FakeDiscover: func(context.Context) (acmeapi.Directory, error) {
+					return test.discoverDir, test.discoverErr
+				},
 			}
 
+			// Mock ACME client builder.
+			clientServerURL := ""
+			var clientKey *rsa.PrivateKey
+
 			// Mock events recorder.
 			recorder := new(controllertest.FakeRecorder)
 			a := Acme{
@@ -562,8 +993,17 @@ func TestAcme_Setup(t *testing.T) {
 				secretsClient:   secretsClient,
 				accountRegistry: ar,
 				keyFromSecret:   kfs,
-				clientBuilder:   clientBuilderMock(&cl),
-				recorder:        recorder,
+				clientBuilder: func(_ *http.Client, config cmacme.ACMEIssuer, pk *rsa.PrivateKey, _ string) acmecl.Interface {
+					clientServerURL = config.Server
+					clientKey = pk
+					return &cl
+				},
+				recorder: recorder,
+
+				secretReadBackoff:     wait.Backoff{Steps: 4, Duration: time.Millisecond},
+				conditionReasonPrefix: test.conditionReasonPrefix,
+				clock:                 fakeclock,
+				bootstrapDeadline:     test.bootstrapDeadline,
 			}
 
 			// Stub the clock to get consistent last transition times on conditions.
@@ -590,6 +1030,17 @@ func TestAcme_Setup(t *testing.T) {
 					addClientWasCalled)
 			}
 
+			// Verify that the ACME clients were built with the server URL
+			// exactly as it is set on the issuer.
+			if clientServerURL != "" && clientServerURL != test.issuer.GetSpec().ACME.Server {
+				t.Errorf("Expected ACME client to be built with server URL %q, got %q",
+					test.issuer.GetSpec().ACME.Server, clientServerURL)
+			}
+			if addClientWasCalled && addClientServerURL != test.issuer.GetSpec().ACME.Server {
+				t.Errorf("Expected Acme.accountsRegistry.AddClient to be called with server URL %q, got %q",
+					test.issuer.GetSpec().ACME.Server, addClientServerURL)
+			}
+
 			// Verify that the expected account value was passed when the
 			// account was registered.
 			if !reflect.DeepEqual(gotAcc, test.expectedRegisteredAcc) {
@@ -606,6 +1057,52 @@ func TestAcme_Setup(t *testing.T) {
 					test.expectedConditions, gotConditions)
 			}
 
+			// Verify that the account key parameters were recorded only if
+			// the account key was generated.
+			if gotParams := a.issuer.GetStatus().ACMEStatus().AccountKeyParameters; !reflect.DeepEqual(gotParams, test.expectedAccountKeyParameters) {
+				t.Errorf("Expected account key parameters: %#+v\ngot: %#+v",
+					test.expectedAccountKeyParameters, gotParams)
+			}
+
+			// Verify that the registration time was recorded only if the
+			// account was newly created.
+			if gotRegisteredAt := a.issuer.GetStatus().ACMEStatus().RegisteredAt; !reflect.DeepEqual(gotRegisteredAt, test.expectedRegisteredAt) {
+				t.Errorf("Expected registered at: %v\ngot: %v",
+					test.expectedRegisteredAt, gotRegisteredAt)
+			}
+
+			// Verify that the terms of service the account was registered
+			// under were recorded.
+			if gotTermsURL := a.issuer.GetStatus().ACMEStatus().TermsOfServiceURL; gotTermsURL != test.expectedTermsOfServiceURL {
+				t.Errorf("Expected terms of service URL: %q\ngot: %q",
+					test.expectedTermsOfServiceURL, gotTermsURL)
+			}
+
+			// Verify that the account key thumbprint and verification time
+			// were recorded only if the account was registered or verified
+			// with the ACME server.
+			var expectedThumbprint string
+			var expectedLastVerifiedAt *metav1.Time
+			if gotAcc != nil && apiutil.IssuerHasCondition(a.issuer, cmapi.IssuerCondition{
+				Type:   cmapi.IssuerConditionReady,
+				Status: cmmeta.ConditionTrue,
+			}) {
+				thumbprint, err := acmeapi.JWKThumbprint(clientKey.Public())
+				if err != nil {
+					t.Fatal(err)
+				}
+				expectedThumbprint = thumbprint
+				expectedLastVerifiedAt = &nowMetaTime
+			}
+			if gotThumbprint := a.issuer.GetStatus().ACMEStatus().AccountKeyThumbprint; gotThumbprint != expectedThumbprint {
+				t.Errorf("Expected account key thumbprint: %q\ngot: %q",
+					expectedThumbprint, gotThumbprint)
+			}
+			if gotLastVerifiedAt := a.issuer.GetStatus().ACMEStatus().LastVerifiedAt; !reflect.DeepEqual(gotLastVerifiedAt, expectedLastVerifiedAt) {
+				t.Errorf("Expected last verified at: %v\ngot: %v",
+					expectedLastVerifiedAt, gotLastVerifiedAt)
+			}
+
 			// Verify that the expected events were recorded.
 			if !util.EqualSorted(test.expectedEvents, recorder.Events) {
 				t.Errorf("Expected events:\n%+#v\ngot:%+#v",
@@ -616,6 +1113,440 @@ func TestAcme_Setup(t *testing.T) {
 	}
 }
 
+func TestAcme_SetupDeprecationWarnings(t *testing.T) {
+	recorder := new(controllertest.FakeRecorder)
+	a := &Acme{recorder: recorder}
+
+	// Make two requests that return the same warning to verify that
+	// warnings are deduplicated.
+	runSetupWithServer(t, a, 2, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Warning", `299 - "the newAccount endpoint is deprecated"`)
+	})
+
+	expectedEvents := []string{
+		fmt.Sprintf("%s %s %s", corev1.EventTypeNormal, successAccountRegistered, messageAccountCreated),
+		fmt.Sprintf("%s %s %s", corev1.EventTypeWarning, warningDeprecation,
+			fmt.Sprintf(messageTemplateDeprecationWarning, "the newAccount endpoint is deprecated")),
+	}
+	if !reflect.DeepEqual(expectedEvents, recorder.Events) {
+		t.Errorf("Expected events:\n%+#v\ngot:%+#v", expectedEvents, recorder.Events)
+	}
+}
+
+func TestAcme_SetupPreferredLanguage(t *testing.T) {
+	tests := map[string]struct {
+		preferredLanguage string
+		expLanguage       string
+	}{
+		"no Accept-Language header is sent if no preferred language is set": {
+			preferredLanguage: "",
+			expLanguage:       "",
+		},
+		"the preferred language is sent in the Accept-Language header": {
+			preferredLanguage: "de-DE",
+			expLanguage:       "de-DE",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			a := &Acme{
+				issuer: gen.Issuer("test-issuer",
+					gen.SetIssuerACMEURL(acmev2Prod),
+					gen.SetIssuerACMEPreferredLanguage(test.preferredLanguage)),
+			}
+
+			var gotLanguage string
+			runSetupWithServer(t, a, 1, func(w http.ResponseWriter, r *http.Request) {
+				gotLanguage = r.Header.Get("Accept-Language")
+			})
+
+			if gotLanguage != test.expLanguage {
+				t.Errorf("unexpected Accept-Language header, exp=%q got=%q", test.expLanguage, gotLanguage)
+			}
+		})
+	}
+}
+
+func TestAcme_SetupHTTPTimeout(t *testing.T) {
+	tests := map[string]struct {
+		httpTimeout time.Duration
+		expTimeout  time.Duration
+	}{
+		"the default timeout is used if no timeout is configured": {
+			httpTimeout: 0,
+			expTimeout:  90 * time.Second,
+		},
+		"the configured timeout is used": {
+			httpTimeout: 5 * time.Minute,
+			expTimeout:  5 * time.Minute,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			a := &Acme{httpTimeout: test.httpTimeout}
+
+			httpClient := runSetupWithServer(t, a, 0, nil)
+
+			if httpClient.Timeout != test.expTimeout {
+				t.Errorf("unexpected HTTP client timeout, exp=%s got=%s", test.expTimeout, httpClient.Timeout)
+			}
+		})
+	}
+}
+
+func TestAcme_SetupClockSkew(t *testing.T) {
+	serverTime := time.Date(2023, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		localTime          time.Time
+		clockSkewThreshold time.Duration
+		expectedEvents     []string
+	}{
+		"clock skew above the threshold emits an event": {
+			localTime:          serverTime.Add(-5 * time.Minute),
+			clockSkewThreshold: time.Minute,
+			expectedEvents: []string{
+				fmt.Sprintf("%s %s %s", corev1.EventTypeNormal, successAccountRegistered, messageAccountCreated),
+				fmt.Sprintf("%s %s %s", corev1.EventTypeWarning, warningClockSkew,
+					fmt.Sprintf(messageTemplateClockSkew, 5*time.Minute, time.Minute)),
+			},
+		},
+		"clock skew with the local clock ahead above the threshold emits an event": {
+			localTime:          serverTime.Add(2 * time.Minute),
+			clockSkewThreshold: time.Minute,
+			expectedEvents: []string{
+				fmt.Sprintf("%s %s %s", corev1.EventTypeNormal, successAccountRegistered, messageAccountCreated),
+				fmt.Sprintf("%s %s %s", corev1.EventTypeWarning, warningClockSkew,
+					fmt.Sprintf(messageTemplateClockSkew, 2*time.Minute, time.Minute)),
+			},
+		},
+		"clock skew below the threshold does not emit an event": {
+			localTime:          serverTime.Add(-30 * time.Second),
+			clockSkewThreshold: time.Minute,
+			expectedEvents: []string{
+				fmt.Sprintf("%s %s %s", corev1.EventTypeNormal, successAccountRegistered, messageAccountCreated),
+			},
+		},
+		"clock skew check is disabled": {
+			localTime:          serverTime.Add(-5 * time.Minute),
+			clockSkewThreshold: 0,
+			expectedEvents: []string{
+				fmt.Sprintf("%s %s %s", corev1.EventTypeNormal, successAccountRegistered, messageAccountCreated),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			recorder := new(controllertest.FakeRecorder)
+			a := &Acme{
+				recorder:           recorder,
+				clock:              fakeclock.NewFakeClock(test.localTime),
+				clockSkewThreshold: test.clockSkewThreshold,
+			}
+
+			runSetupWithServer(t, a, 1, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Date", serverTime.Format(http.TimeFormat))
+			})
+
+			if !reflect.DeepEqual(test.expectedEvents, recorder.Events) {
+				t.Errorf("Expected events:\n%+#v\ngot:%+#v", test.expectedEvents, recorder.Events)
+			}
+		})
+	}
+}
+
+func TestAcme_SetupCorrelationID(t *testing.T) {
+	tests := map[string]struct {
+		correlationIDHeader string
+		expHeader           bool
+	}{
+		"no correlation ID is sent if no header is configured": {
+			correlationIDHeader: "",
+			expHeader:           false,
+		},
+		"a correlation ID is sent in the configured header": {
+			correlationIDHeader: "X-Correlation-ID",
+			expHeader:           true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			a := &Acme{correlationIDHeader: test.correlationIDHeader}
+
+			var gotIDs []string
+			runSetupWithServer(t, a, 2, func(w http.ResponseWriter, r *http.Request) {
+				gotIDs = append(gotIDs, r.Header.Get("X-Correlation-ID"))
+			})
+
+			if len(gotIDs) != 2 {
+				t.Fatalf("expected 2 requests, got %d", len(gotIDs))
+			}
+			if !test.expHeader {
+				if gotIDs[0] != "" || gotIDs[1] != "" {
+					t.Errorf("expected no correlation ID to be sent, got %q", gotIDs)
+				}
+				return
+			}
+			// All requests made during a single Setup share the same ID.
+			if gotIDs[0] == "" || gotIDs[0] != gotIDs[1] {
+				t.Errorf("expected the same correlation ID on every request, got %q", gotIDs)
+			}
+		})
+	}
+}
+
+func TestAcme_RecordEvent(t *testing.T) {
+	tests := map[string]struct {
+		annotations         map[string]string
+		eventAnnotationKeys []string
+		expAnnotations      []map[string]string
+	}{
+		"no event annotation keys configured": {
+			annotations:    map[string]string{"example.com/team": "a"},
+			expAnnotations: nil,
+		},
+		"configured annotation keys are not set on the issuer": {
+			annotations:         map[string]string{"example.com/team": "a"},
+			eventAnnotationKeys: []string{"example.com/environment"},
+			expAnnotations:      nil,
+		},
+		"only the configured annotation keys set on the issuer are attached": {
+			annotations: map[string]string{
+				"example.com/team":        "a",
+				"example.com/environment": "production",
+				"example.com/unrelated":   "value",
+			},
+			eventAnnotationKeys: []string{"example.com/team", "example.com/environment", "example.com/cost-center"},
+			expAnnotations: []map[string]string{{
+				"example.com/team":        "a",
+				"example.com/environment": "production",
+			}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			iss := gen.Issuer("test-issuer")
+			iss.Annotations = test.annotations
+
+			recorder := new(controllertest.FakeRecorder)
+			a := Acme{
+				issuer:              iss,
+				recorder:            recorder,
+				eventAnnotationKeys: test.eventAnnotationKeys,
+			}
+			a.recordEvent(corev1.EventTypeWarning, errorAccountUpdateFailed, "100% failed")
+
+			expEvents := []string{fmt.Sprintf("%s %s %s", corev1.EventTypeWarning, errorAccountUpdateFailed, "100% failed")}
+			if !reflect.DeepEqual(expEvents, recorder.Events) {
+				t.Errorf("Expected events:\n%+#v\ngot:%+#v", expEvents, recorder.Events)
+			}
+			if !reflect.DeepEqual(test.expAnnotations, recorder.Annotations) {
+				t.Errorf("Expected event annotations:\n%+#v\ngot:%+#v", test.expAnnotations, recorder.Annotations)
+			}
+		})
+	}
+}
+
+func TestEnsureEmailUpToDate(t *testing.T) {
+	tests := map[string]struct {
+		contacts       []string
+		specEmail      string
+		expUpdate      bool
+		expNewContacts []string
+	}{
+		"identical contacts do not trigger an update": {
+			contacts:  []string{"mailto:test@example.com"},
+			specEmail: "test@example.com",
+			expUpdate: false,
+		},
+		"contacts that only differ in case do not trigger an update": {
+			contacts:  []string{"MAILTO:Test@Example.COM"},
+			specEmail: "test@EXAMPLE.com",
+			expUpdate: false,
+		},
+		"contacts without the mailto scheme do not trigger an update": {
+			contacts:  []string{"test@example.com"},
+			specEmail: "test@example.com",
+			expUpdate: false,
+		},
+		"duplicate contacts with surrounding whitespace do not trigger an update": {
+			contacts:  []string{" mailto:test@example.com", "mailto:TEST@example.com "},
+			specEmail: "test@example.com",
+			expUpdate: false,
+		},
+		"no contacts and no email do not trigger an update": {
+			contacts:  []string{},
+			specEmail: "",
+			expUpdate: false,
+		},
+		"a changed email triggers an update": {
+			contacts:       []string{"mailto:old@example.com"},
+			specEmail:      "new@example.com",
+			expUpdate:      true,
+			expNewContacts: []string{"mailto:new@example.com"},
+		},
+		"an additional contact triggers an update": {
+			contacts:       []string{"mailto:test@example.com", "mailto:other@example.com"},
+			specEmail:      "test@example.com",
+			expUpdate:      true,
+			expNewContacts: []string{"mailto:test@example.com"},
+		},
+		"removing the email triggers an update": {
+			contacts:       []string{"mailto:test@example.com"},
+			specEmail:      "",
+			expUpdate:      true,
+			expNewContacts: nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var updatedAcc *acmeapi.Account
+			cl := &acmecl.FakeACME{
+				FakeUpdateReg: func(_ context.Context, a *acmeapi.Account) (*acmeapi.Account, error) {
+					updatedAcc = a
+					return a, nil
+				},
+			}
+
+			_, registeredEmail, err := ensureEmailUpToDate(context.Background(), cl, &acmeapi.Account{Contact: test.contacts}, test.specEmail)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if registeredEmail != test.specEmail {
+				t.Errorf("expected registered email %q, got %q", test.specEmail, registeredEmail)
+			}
+
+			if (updatedAcc != nil) != test.expUpdate {
+				t.Fatalf("expected UpdateReg to be called: %v, was called: %v", test.expUpdate, updatedAcc != nil)
+			}
+
+			if updatedAcc != nil && !reflect.DeepEqual(updatedAcc.Contact, test.expNewContacts) {
+				t.Errorf("expected updated contacts %q, got %q", test.expNewContacts, updatedAcc.Contact)
+			}
+		})
+	}
+}
+
+func TestNormalizeContacts(t *testing.T) {
+	a := []string{"mailto:B@example.com", "a@example.com"}
+	b := []string{"MailTo:a@EXAMPLE.com", "mailto:b@example.com", ""}
+
+	normA, normB := normalizeContacts(a), normalizeContacts(b)
+	if !reflect.DeepEqual(normA, normB) {
+		t.Errorf("expected %q and %q to be equivalent, got %q and %q", a, b, normA, normB)
+	}
+
+	exp := []string{"mailto:a@example.com", "mailto:b@example.com"}
+	if !reflect.DeepEqual(normA, exp) {
+		t.Errorf("expected normalized contacts %q, got %q", exp, normA)
+	}
+}
+
+func TestIsExternalAccountBindingError(t *testing.T) {
+	eab := &acmeapi.ExternalAccountBinding{KID: "test", Key: []byte("test")}
+	tests := map[string]struct {
+		err *acmeapi.Error
+		eab *acmeapi.ExternalAccountBinding
+		exp bool
+	}{
+		"external account required with an EAB configured": {
+			err: &acmeapi.Error{ProblemType: "urn:ietf:params:acme:error:externalAccountRequired"},
+			eab: eab,
+			exp: true,
+		},
+		"unauthorized with a detail mentioning the external account binding": {
+			err: &acmeapi.Error{ProblemType: "urn:ietf:params:acme:error:unauthorized", Detail: "External account binding MAC is invalid"},
+			eab: eab,
+			exp: true,
+		},
+		"malformed with a detail mentioning the EAB": {
+			err: &acmeapi.Error{ProblemType: "urn:ietf:params:acme:error:malformed", Detail: "invalid EAB key ID"},
+			eab: eab,
+			exp: true,
+		},
+		"unauthorized for an unrelated reason": {
+			err: &acmeapi.Error{ProblemType: "urn:ietf:params:acme:error:unauthorized", Detail: "account is not reachable"},
+			eab: eab,
+			exp: false,
+		},
+		"no EAB configured": {
+			err: &acmeapi.Error{ProblemType: "urn:ietf:params:acme:error:unauthorized", Detail: "the external account binding is invalid"},
+			exp: false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := isExternalAccountBindingError(test.err, test.eab); got != test.exp {
+				t.Errorf("expected %v, got %v", test.exp, got)
+			}
+		})
+	}
+}
+
+// runSetupWithServer runs Setup on the given Acme, stubbing any of its
+// dependencies that are not set. The stubbed ACME client makes the given
+// number of requests to a test server serving handler during account
+// registration, using the HTTP client that Setup built for it, so that the
+// behaviour of that HTTP client can be tested. The HTTP client is returned.
+func runSetupWithServer(t *testing.T, a *Acme, requests int, handler http.HandlerFunc) *http.Client {
+	t.Helper()
+
+	if handler == nil {
+		handler = func(http.ResponseWriter, *http.Request) {}
+	}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	if a.issuer == nil {
+		a.issuer = gen.Issuer("test-issuer",
+			gen.SetIssuerACMEURL(acmev2Prod))
+	}
+	if a.recorder == nil {
+		a.recorder = new(controllertest.FakeRecorder)
+	}
+	if a.clock == nil {
+		a.clock = fakeclock.NewFakeClock(time.Now())
+	}
+	a.secretsClient = coreclients.NewFakeSecretsGetter()
+	a.accountRegistry = &fakeregistry.FakeRegistry{
+		RemoveClientFunc: func(string) {},
+		AddClientFunc:    func(string, cmacme.ACMEIssuer, *rsa.PrivateKey, string) {},
+	}
+	a.keyFromSecret = keyFromSecretMockBuilder(new(bool), mustGenerateRSAKey(t), nil)
+	a.secretReadBackoff = wait.Backoff{Steps: 1}
+	a.metrics = metrics.New(logf.Log, fakeclock.NewFakeClock(time.Now()))
+
+	var httpClient *http.Client
+	a.clientBuilder = func(c *http.Client, _ cmacme.ACMEIssuer, _ *rsa.PrivateKey, _ string) acmecl.Interface {
+		httpClient = c
+		return &acmecl.FakeACME{
+			FakeRegister: func(_ context.Context, acc *acmeapi.Account, _ func(string) bool) (*acmeapi.Account, error) {
+				for i := 0; i < requests; i++ {
+					resp, err := httpClient.Get(server.URL)
+					if err != nil {
+						return nil, err
+					}
+					resp.Body.Close()
+				}
+				return acc, nil
+			},
+		}
+	}
+
+	if err := a.Setup(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return httpClient
+}
+
 // keyFromSecretMockBuilder returns a mock implementation of keyFromSecretFunc.
 func keyFromSecretMockBuilder(wasCalled *bool, key crypto.Signer, err error) keyFromSecretFunc {
 	return func(context.Context, string, string, string) (crypto.Signer, error) {
@@ -624,12 +1555,6 @@ func keyFromSecretMockBuilder(wasCalled *bool, key crypto.Signer, err error) key
 	}
 }
 
-func clientBuilderMock(cl acmecl.Interface) accounts.NewClientFunc {
-	return func(*http.Client, cmacme.ACMEIssuer, *rsa.PrivateKey, string) acmecl.Interface {
-		return cl
-	}
-}
-
 func parseURLErr(s string) error {
 	_, err := url.Parse(s)
 	return err
@@ -644,6 +1569,8 @@ func mustGenerateEDCSAKey(t *testing.T) crypto.Signer {
 	return key
 }
 
+// mustGenerateInconsistentRSAKey returns an RSA private key whose private
+// exponent does not match its public exponent, so that it fails validation.
 func mustGenerateRSAKey(t *testing.T) crypto.Signer {
 	t.Helper()
 	key, err := pki.GenerateRSAPrivateKey(pki.MinRSAKeySize)
@@ -18,6 +18,8 @@ package metrics
 
 import (
 	"time"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 )
 
 // ObserveACMERequestDuration increases bucket counters for that ACME client duration.
@@ -29,3 +31,10 @@ func (m *Metrics) ObserveACMERequestDuration(duration time.Duration, labels ...s
 func (m *Metrics) IncrementACMERequestCount(labels ...string) {
 	m.acmeClientRequestCount.WithLabelValues(labels...).Inc()
 }
+
+// IncrementACMERateLimitedCount increases the counter of Orders that an ACME
+// server refused to create because of a rate limit, for the given issuer. The
+// namespace is empty for ClusterIssuers.
+func (m *Metrics) IncrementACMERateLimitedCount(namespace string, issuerRef cmmeta.ObjectReference) {
+	m.acmeRateLimitedCount.WithLabelValues(namespace, issuerRef.Name, issuerRef.Kind, issuerRef.Group).Inc()
+}
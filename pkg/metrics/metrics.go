@@ -21,8 +21,17 @@ limitations under the License.
 // certificate_ready_status{name, namespace, condition, issuer_name, issuer_kind, issuer_group}
 // acme_client_request_count{"scheme", "host", "path", "method", "status"}
 // acme_client_request_duration_seconds{"scheme", "host", "path", "method", "status"}
+// acme_rate_limited_count{"namespace", "issuer_name", "issuer_kind", "issuer_group"}
 // venafi_client_request_duration_seconds{"scheme", "host", "path", "method", "status"}
 // controller_sync_call_count{"controller"}
+// certificate_issuance_triggered_count{"reason"}
+// workqueue_depth{"name"}
+// workqueue_adds_total{"name"}
+// workqueue_queue_duration_seconds{"name"}
+// workqueue_work_duration_seconds{"name"}
+// workqueue_unfinished_work_seconds{"name"}
+// workqueue_longest_running_processor_seconds{"name"}
+// workqueue_retries_total{"name"}
 package metrics
 
 import (
@@ -33,6 +42,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/clock"
 
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
@@ -59,9 +69,13 @@ type Metrics struct {
 	certificateReadyStatus             *prometheus.GaugeVec
 	acmeClientRequestDurationSeconds   *prometheus.SummaryVec
 	acmeClientRequestCount             *prometheus.CounterVec
+	acmeRateLimitedCount               *prometheus.CounterVec
 	venafiClientRequestDurationSeconds *prometheus.SummaryVec
 	controllerSyncCallCount            *prometheus.CounterVec
 	controllerSyncErrorCount           *prometheus.CounterVec
+	certificateIssuanceTriggeredCount  *prometheus.CounterVec
+	keyGenerationDurationSeconds       *prometheus.GaugeVec
+	workqueue                          *workqueueMetrics
 }
 
 var readyConditionStatuses = [...]cmmeta.ConditionStatus{cmmeta.ConditionTrue, cmmeta.ConditionFalse, cmmeta.ConditionUnknown}
@@ -152,6 +166,15 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 			[]string{"scheme", "host", "path", "method", "status"},
 		)
 
+		acmeRateLimitedCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "acme_rate_limited_count",
+				Help:      "The number of times an ACME server refused to create an Order because a rate limit was exceeded, by the issuer the Order was created for.",
+			},
+			[]string{"namespace", "issuer_name", "issuer_kind", "issuer_group"},
+		)
+
 		// venafiClientRequestDurationSeconds is a Prometheus summary to
 		// collect api call latencies for the Venafi client. This
 		// metric is in alpha since cert-manager 1.9. Move it to GA once
@@ -184,6 +207,26 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 			},
 			[]string{"controller"},
 		)
+
+		certificateIssuanceTriggeredCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "certificate_issuance_triggered_count",
+				Help:      "The number of times the issuance of a Certificate was triggered, by the reason it was triggered for, such as a missing Secret or a private key that does not match the issued certificate.",
+			},
+			[]string{"reason"},
+		)
+
+		// keyGenerationDurationSeconds is only populated if the key
+		// generation benchmark is enabled at startup.
+		keyGenerationDurationSeconds = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "key_generation_benchmark_duration_seconds",
+				Help:      "The time in seconds it took to generate a private key of the given algorithm and size during the startup key generation benchmark.",
+			},
+			[]string{"algorithm", "size"},
+		)
 	)
 
 	// Create server and register Prometheus metrics handler
@@ -197,10 +240,14 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 		certificateRenewalTimeSeconds:      certificateRenewalTimeSeconds,
 		certificateReadyStatus:             certificateReadyStatus,
 		acmeClientRequestCount:             acmeClientRequestCount,
+		acmeRateLimitedCount:               acmeRateLimitedCount,
 		acmeClientRequestDurationSeconds:   acmeClientRequestDurationSeconds,
 		venafiClientRequestDurationSeconds: venafiClientRequestDurationSeconds,
 		controllerSyncCallCount:            controllerSyncCallCount,
 		controllerSyncErrorCount:           controllerSyncErrorCount,
+		certificateIssuanceTriggeredCount:  certificateIssuanceTriggeredCount,
+		keyGenerationDurationSeconds:       keyGenerationDurationSeconds,
+		workqueue:                          newWorkqueueMetrics(),
 	}
 
 	return m
@@ -216,8 +263,17 @@ func (m *Metrics) NewServer(ln net.Listener) *http.Server {
 	m.registry.MustRegister(m.acmeClientRequestDurationSeconds)
 	m.registry.MustRegister(m.venafiClientRequestDurationSeconds)
 	m.registry.MustRegister(m.acmeClientRequestCount)
+	m.registry.MustRegister(m.acmeRateLimitedCount)
 	m.registry.MustRegister(m.controllerSyncCallCount)
 	m.registry.MustRegister(m.controllerSyncErrorCount)
+	m.registry.MustRegister(m.certificateIssuanceTriggeredCount)
+	m.registry.MustRegister(m.keyGenerationDurationSeconds)
+	m.registry.MustRegister(m.workqueue.collectors()...)
+
+	// The work queue metrics provider is global, and only the first provider
+	// that is set is used. It must be set before the controllers create their
+	// work queues.
+	workqueue.SetProvider(m.workqueue)
 
 	mux := http.NewServeMux()
 	mux.Handle("/metrics", promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
@@ -242,3 +298,9 @@ func (m *Metrics) IncrementSyncCallCount(controllerName string) {
 func (m *Metrics) IncrementSyncErrorCount(controllerName string) {
 	m.controllerSyncErrorCount.WithLabelValues(controllerName).Inc()
 }
+
+// IncrementCertificateIssuanceTriggeredCount will increase the count of
+// Certificate issuances triggered for the given reason.
+func (m *Metrics) IncrementCertificateIssuanceTriggeredCount(reason string) {
+	m.certificateIssuanceTriggeredCount.WithLabelValues(reason).Inc()
+}
@@ -28,6 +28,8 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	fakeclock "k8s.io/utils/clock/testing"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 )
 
 func Test_clockTimeSeconds(t *testing.T) {
@@ -68,3 +70,65 @@ certmanager_clock_time_seconds_gauge %f
 		})
 	}
 }
+
+func Test_workqueueMetrics(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), fakeclock.NewFakeClock(time.Now()))
+
+	m.workqueue.NewDepthMetric("certificates-issuing").Inc()
+	m.workqueue.NewAddsMetric("certificates-issuing").Inc()
+	m.workqueue.NewRetriesMetric("certificates-issuing").Inc()
+
+	expected := `
+# HELP certmanager_workqueue_depth The current depth of the work queue.
+# TYPE certmanager_workqueue_depth gauge
+certmanager_workqueue_depth{name="certificates-issuing"} 1
+# HELP certmanager_workqueue_adds_total The total number of adds handled by the work queue.
+# TYPE certmanager_workqueue_adds_total counter
+certmanager_workqueue_adds_total{name="certificates-issuing"} 1
+# HELP certmanager_workqueue_retries_total The total number of retries handled by the work queue.
+# TYPE certmanager_workqueue_retries_total counter
+certmanager_workqueue_retries_total{name="certificates-issuing"} 1
+`
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(m.workqueue.collectors()...)
+	assert.NoError(t,
+		testutil.GatherAndCompare(registry, strings.NewReader(expected),
+			"certmanager_workqueue_depth", "certmanager_workqueue_adds_total", "certmanager_workqueue_retries_total"),
+	)
+}
+
+func Test_certificateIssuanceTriggeredCount(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), fakeclock.NewFakeClock(time.Now()))
+
+	m.IncrementCertificateIssuanceTriggeredCount("DoesNotExist")
+	m.IncrementCertificateIssuanceTriggeredCount("DoesNotExist")
+	m.IncrementCertificateIssuanceTriggeredCount("SecretMismatch")
+
+	expected := `
+# HELP certmanager_certificate_issuance_triggered_count The number of times the issuance of a Certificate was triggered, by the reason it was triggered for, such as a missing Secret or a private key that does not match the issued certificate.
+# TYPE certmanager_certificate_issuance_triggered_count counter
+certmanager_certificate_issuance_triggered_count{reason="DoesNotExist"} 2
+certmanager_certificate_issuance_triggered_count{reason="SecretMismatch"} 1
+`
+	assert.NoError(t,
+		testutil.CollectAndCompare(m.certificateIssuanceTriggeredCount, strings.NewReader(expected), "certmanager_certificate_issuance_triggered_count"),
+	)
+}
+
+func Test_acmeRateLimitedCount(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), fakeclock.NewFakeClock(time.Now()))
+
+	m.IncrementACMERateLimitedCount("default", cmmeta.ObjectReference{Name: "letsencrypt", Kind: "Issuer", Group: "cert-manager.io"})
+	m.IncrementACMERateLimitedCount("", cmmeta.ObjectReference{Name: "letsencrypt", Kind: "ClusterIssuer", Group: "cert-manager.io"})
+	m.IncrementACMERateLimitedCount("", cmmeta.ObjectReference{Name: "letsencrypt", Kind: "ClusterIssuer", Group: "cert-manager.io"})
+
+	expected := `
+# HELP certmanager_acme_rate_limited_count The number of times an ACME server refused to create an Order because a rate limit was exceeded, by the issuer the Order was created for.
+# TYPE certmanager_acme_rate_limited_count counter
+certmanager_acme_rate_limited_count{issuer_group="cert-manager.io",issuer_kind="ClusterIssuer",issuer_name="letsencrypt",namespace=""} 2
+certmanager_acme_rate_limited_count{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="letsencrypt",namespace="default"} 1
+`
+	assert.NoError(t,
+		testutil.CollectAndCompare(m.acmeRateLimitedCount, strings.NewReader(expected), "certmanager_acme_rate_limited_count"),
+	)
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// workqueueMetrics implements workqueue.MetricsProvider, so that the metrics
+// of the named work queues used by each controller are exposed by
+// cert-manager.
+type workqueueMetrics struct {
+	depth                   *prometheus.GaugeVec
+	adds                    *prometheus.CounterVec
+	latency                 *prometheus.HistogramVec
+	workDuration            *prometheus.HistogramVec
+	unfinishedWorkSeconds   *prometheus.GaugeVec
+	longestRunningProcessor *prometheus.GaugeVec
+	retries                 *prometheus.CounterVec
+}
+
+var _ workqueue.MetricsProvider = &workqueueMetrics{}
+
+func newWorkqueueMetrics() *workqueueMetrics {
+	return &workqueueMetrics{
+		depth: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Subsystem: "workqueue",
+				Name:      "depth",
+				Help:      "The current depth of the work queue.",
+			},
+			[]string{"name"},
+		),
+		adds: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Subsystem: "workqueue",
+				Name:      "adds_total",
+				Help:      "The total number of adds handled by the work queue.",
+			},
+			[]string{"name"},
+		),
+		latency: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Subsystem: "workqueue",
+				Name:      "queue_duration_seconds",
+				Help:      "How long in seconds an item stays in the work queue before being requested.",
+				Buckets:   prometheus.ExponentialBuckets(10e-9, 10, 10),
+			},
+			[]string{"name"},
+		),
+		workDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Subsystem: "workqueue",
+				Name:      "work_duration_seconds",
+				Help:      "How long in seconds processing an item from the work queue takes.",
+				Buckets:   prometheus.ExponentialBuckets(10e-9, 10, 10),
+			},
+			[]string{"name"},
+		),
+		unfinishedWorkSeconds: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Subsystem: "workqueue",
+				Name:      "unfinished_work_seconds",
+				Help:      "How many seconds of work has been done that is in progress and hasn't been observed by work_duration_seconds.",
+			},
+			[]string{"name"},
+		),
+		longestRunningProcessor: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Subsystem: "workqueue",
+				Name:      "longest_running_processor_seconds",
+				Help:      "How many seconds the longest running processor of the work queue has been running.",
+			},
+			[]string{"name"},
+		),
+		retries: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Subsystem: "workqueue",
+				Name:      "retries_total",
+				Help:      "The total number of retries handled by the work queue.",
+			},
+			[]string{"name"},
+		),
+	}
+}
+
+func (w *workqueueMetrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		w.depth,
+		w.adds,
+		w.latency,
+		w.workDuration,
+		w.unfinishedWorkSeconds,
+		w.longestRunningProcessor,
+		w.retries,
+	}
+}
+
+func (w *workqueueMetrics) NewDepthMetric(name string) workqueue.GaugeMetric {
+	return w.depth.WithLabelValues(name)
+}
+
+func (w *workqueueMetrics) NewAddsMetric(name string) workqueue.CounterMetric {
+	return w.adds.WithLabelValues(name)
+}
+
+func (w *workqueueMetrics) NewLatencyMetric(name string) workqueue.HistogramMetric {
+	return w.latency.WithLabelValues(name)
+}
+
+func (w *workqueueMetrics) NewWorkDurationMetric(name string) workqueue.HistogramMetric {
+	return w.workDuration.WithLabelValues(name)
+}
+
+func (w *workqueueMetrics) NewUnfinishedWorkSecondsMetric(name string) workqueue.SettableGaugeMetric {
+	return w.unfinishedWorkSeconds.WithLabelValues(name)
+}
+
+func (w *workqueueMetrics) NewLongestRunningProcessorSecondsMetric(name string) workqueue.SettableGaugeMetric {
+	return w.longestRunningProcessor.WithLabelValues(name)
+}
+
+func (w *workqueueMetrics) NewRetriesMetric(name string) workqueue.CounterMetric {
+	return w.retries.WithLabelValues(name)
+}
@@ -30,3 +30,16 @@ func IsInvalidData(err error) bool {
 	}
 	return true
 }
+
+type invalidSecretTypeError struct{ error }
+
+func NewInvalidSecretType(str string, obj ...interface{}) error {
+	return &invalidSecretTypeError{error: fmt.Errorf(str, obj...)}
+}
+
+func IsInvalidSecretType(err error) bool {
+	if _, ok := err.(*invalidSecretTypeError); !ok {
+		return false
+	}
+	return true
+}
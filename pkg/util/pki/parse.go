@@ -33,6 +33,9 @@ func DecodePrivateKeyBytes(keyBytes []byte) (crypto.Signer, error) {
 	if block == nil {
 		return nil, errors.NewInvalidData("error decoding private key PEM block")
 	}
+	// The DER bytes are decoded into a new buffer, which is no longer needed
+	// once the key has been parsed.
+	defer Zeroize(block.Bytes)
 
 	switch block.Type {
 	case "PRIVATE KEY":
@@ -76,6 +79,7 @@ func DecodePKCS1PrivateKeyBytes(keyBytes []byte) (*rsa.PrivateKey, error) {
 	if block == nil {
 		return nil, errors.NewInvalidData("error decoding private key PEM block")
 	}
+	defer Zeroize(block.Bytes)
 	// parse the private key
 	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
 	if err != nil {
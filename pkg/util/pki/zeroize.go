@@ -0,0 +1,35 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import "runtime"
+
+// Zeroize overwrites every byte of the given buffer with zero. It should be
+// used to wipe transient buffers holding PEM or DER encoded key material once
+// they are no longer needed, to reduce the window in which key material
+// lingers in memory.
+// Note that this cannot wipe parsed keys, such as an *rsa.PrivateKey, or any
+// copies of the buffer that have been made by the runtime or other libraries.
+// Buffers that are shared with other users, such as the data of a Secret
+// returned by an informer cache, must never be zeroized.
+func Zeroize(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+	// Ensure the writes above are not optimised away.
+	runtime.KeepAlive(b)
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"testing"
+)
+
+func TestZeroize(t *testing.T) {
+	tests := map[string][]byte{
+		"nil buffer":   nil,
+		"empty buffer": {},
+		"key material": EncodePKCS1PrivateKey(mustGenerateRSA(t, MinRSAKeySize).(*rsa.PrivateKey)),
+	}
+
+	for name, buf := range tests {
+		t.Run(name, func(t *testing.T) {
+			Zeroize(buf)
+			if !bytes.Equal(buf, make([]byte, len(buf))) {
+				t.Errorf("expected buffer to be zeroed, got %v", buf)
+			}
+		})
+	}
+}
+
+func TestZeroizeSubslice(t *testing.T) {
+	buf := []byte("0123456789")
+	Zeroize(buf[2:5])
+	if exp := []byte("01\x00\x00\x0056789"); !bytes.Equal(buf, exp) {
+		t.Errorf("expected only the subslice to be zeroed, exp=%q got=%q", exp, buf)
+	}
+}
@@ -157,6 +157,16 @@ func SetIssuerACMESkipTLSVerify(shouldSkip bool) IssuerModifier {
 	}
 }
 
+func SetIssuerACMEPreferredLanguage(language string) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		spec := iss.GetSpec()
+		if spec.ACME == nil {
+			spec.ACME = &cmacme.ACMEIssuer{}
+		}
+		spec.ACME.PreferredLanguage = language
+	}
+}
+
 func SetIssuerACMEDisableAccountKeyGeneration(disabled bool) IssuerModifier {
 	return func(iss v1.GenericIssuer) {
 		spec := iss.GetSpec()
@@ -167,6 +177,26 @@ func SetIssuerACMEDisableAccountKeyGeneration(disabled bool) IssuerModifier {
 	}
 }
 
+func SetIssuerACMERequireEmail(required bool) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		spec := iss.GetSpec()
+		if spec.ACME == nil {
+			spec.ACME = &cmacme.ACMEIssuer{}
+		}
+		spec.ACME.RequireEmail = required
+	}
+}
+
+func SetIssuerACMEAcceptedTermsOfServiceURL(url string) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		spec := iss.GetSpec()
+		if spec.ACME == nil {
+			spec.ACME = &cmacme.ACMEIssuer{}
+		}
+		spec.ACME.AcceptedTermsOfServiceURL = url
+	}
+}
+
 func SetIssuerACMEEAB(keyID, secretName string) IssuerModifier {
 	return func(iss v1.GenericIssuer) {
 		spec := iss.GetSpec()
@@ -226,6 +256,16 @@ func SetIssuerACMELastRegisteredEmail(email string) IssuerModifier {
 	}
 }
 
+func SetIssuerACMETermsOfServiceURL(url string) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		status := iss.GetStatus()
+		if status.ACME == nil {
+			status.ACME = &cmacme.ACMEIssuerStatus{}
+		}
+		status.ACME.TermsOfServiceURL = url
+	}
+}
+
 func SetIssuerCA(a v1.CAIssuer) IssuerModifier {
 	return func(iss v1.GenericIssuer) {
 		iss.GetSpec().CA = &a